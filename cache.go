@@ -0,0 +1,175 @@
+package clash
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Header set on responses which were served from the client's cache rather than the network.
+const FromCacheHeader = "X-From-Cache"
+
+// Header set on cached responses which the API confirmed were still current with a 304 Not Modified.
+const RevalidatedHeader = "X-Cache-Revalidated"
+
+// A cached API response.
+type CacheEntry struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	ETag       string
+	Expires    time.Time
+}
+
+// Whether the entry can be served without revalidating it with the API.
+func (e *CacheEntry) Fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// build a response from the cache entry, marked with the given header (FromCacheHeader or RevalidatedHeader).
+func (e *CacheEntry) response(req *http.Request, mark string) *http.Response {
+	header := e.Header.Clone()
+
+	if header == nil {
+		header = http.Header{}
+	}
+
+	header.Set(mark, "1")
+
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// Storage for cached responses. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+}
+
+// Report whether a response returned by Client.Do was served from the cache without contacting the API.
+func FromCache(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(FromCacheHeader) != ""
+}
+
+// Report whether a response returned by Client.Do is a cached body the API revalidated with a 304 Not Modified.
+func Revalidated(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(RevalidatedHeader) != ""
+}
+
+// An in-memory Cache which evicts the least recently used entry once full.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// Create an in-memory cache holding at most capacity responses.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (l *LRUCache) Get(key string) (*CacheEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.items[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	l.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+func (l *LRUCache) Set(key string, entry *CacheEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.items[key]; ok {
+		el.Value.(*lruItem).entry = entry
+		l.order.MoveToFront(el)
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&lruItem{key, entry})
+
+	for l.capacity > 0 && l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruItem).key)
+	}
+}
+
+// Number of entries currently cached.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.order.Len()
+}
+
+// cache key for a request: method, full URL and a hash of the credentials, so responses are never shared between tokens.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.Method + " " + req.URL.String() + " " + hex.EncodeToString(sum[:8])
+}
+
+// work out how long a response may be cached for from its Cache-Control and Age headers.
+// The second value is false if the response must not be stored at all.
+func cacheExpiry(header http.Header) (time.Time, bool) {
+	now := time.Now()
+	maxAge := -1
+
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+
+		switch {
+		case directive == "no-store":
+			return time.Time{}, false
+		case directive == "no-cache":
+			maxAge = 0
+		case strings.HasPrefix(directive, "max-age="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil && maxAge != 0 {
+				maxAge = n
+			}
+		}
+	}
+
+	if maxAge < 0 {
+		// no caching information; only keep it if it can be revalidated
+		return now, header.Get("ETag") != ""
+	}
+
+	if age, err := strconv.Atoi(header.Get("Age")); err == nil {
+		maxAge -= age
+	}
+
+	return now.Add(time.Duration(maxAge) * time.Second), true
+}
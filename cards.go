@@ -0,0 +1,199 @@
+package clash
+
+import (
+	"context"
+	"strings"
+)
+
+// A card, either from the catalogue (ID, rarity, cost and max levels) or from a player's
+// collection or deck, which adds the player's level, count and evolution.
+type Card struct {
+	ID                int      `json:"id"`
+	Name              string   `json:"name"`
+	Rarity            string   `json:"rarity"`
+	ElixirCost        int      `json:"elixirCost"`
+	Level             int      `json:"level"`
+	MaxLevel          int      `json:"maxLevel"`
+	EvolutionLevel    int      `json:"evolutionLevel,omitempty"`
+	MaxEvolutionLevel int      `json:"maxEvolutionLevel,omitempty"`
+	Count             int      `json:"count"`
+	IconUrls          IconUrls `json:"iconUrls"`
+	StarLevel         int      `json:"starLevel"`
+}
+
+// Return the internal client level for the card, as these are zero-indexed
+func (c *Card) ClientLevel() int {
+	return c.Level - 1
+}
+
+// Whether the card can be evolved at all.
+func (c *Card) CanEvolve() bool {
+	return c.MaxEvolutionLevel > 0
+}
+
+type FavouriteCard struct {
+	Name     string   `json:"name"`
+	ID       int      `json:"id"`
+	MaxLevel int      `json:"maxLevel"`
+	IconUrls IconUrls `json:"iconUrls"`
+}
+
+type IconUrls struct {
+	Medium          string `json:"medium"`
+	EvolutionMedium string `json:"evolutionMedium,omitempty"`
+}
+
+// Every card in the game, plus support items such as tower troops.
+type CardList struct {
+	Items        []Card `json:"items"`
+	SupportItems []Card `json:"supportItems"`
+}
+
+// An in-memory index of the card catalogue, for looking up card metadata which isn't included
+// in players' collections, decks and battle logs.
+type CardCatalogue struct {
+	byID   map[int]Card
+	byName map[string]Card
+}
+
+// Build a catalogue from a card list, including its support items.
+func NewCardCatalogue(list CardList) *CardCatalogue {
+	catalogue := &CardCatalogue{
+		byID:   make(map[int]Card),
+		byName: make(map[string]Card),
+	}
+
+	for _, card := range append(append([]Card{}, list.Items...), list.SupportItems...) {
+		catalogue.byID[card.ID] = card
+		catalogue.byName[strings.ToLower(card.Name)] = card
+	}
+
+	return catalogue
+}
+
+// Number of cards in the catalogue.
+func (c *CardCatalogue) Len() int {
+	return len(c.byID)
+}
+
+// Find a card by its ID.
+func (c *CardCatalogue) ByID(id int) (Card, bool) {
+	card, ok := c.byID[id]
+	return card, ok
+}
+
+// Find a card by its (English) name, ignoring case.
+func (c *CardCatalogue) ByName(name string) (Card, bool) {
+	card, ok := c.byName[strings.ToLower(name)]
+	return card, ok
+}
+
+// Fill in any catalogue metadata missing from a card, matching it by ID or otherwise by name.
+// Player-specific fields (level, count, evolution level and star level) are kept as they are.
+// The card is returned unchanged if it isn't in the catalogue.
+func (c *CardCatalogue) Resolve(card Card) Card {
+	known, ok := c.ByID(card.ID)
+
+	if !ok || card.ID == 0 {
+		if known, ok = c.ByName(card.Name); !ok {
+			return card
+		}
+	}
+
+	card.ID = known.ID
+	card.Name = known.Name
+	card.Rarity = known.Rarity
+	card.ElixirCost = known.ElixirCost
+	card.MaxLevel = known.MaxLevel
+	card.MaxEvolutionLevel = known.MaxEvolutionLevel
+
+	if card.IconUrls.Medium == "" {
+		card.IconUrls.Medium = known.IconUrls.Medium
+	}
+
+	if card.IconUrls.EvolutionMedium == "" {
+		card.IconUrls.EvolutionMedium = known.IconUrls.EvolutionMedium
+	}
+
+	return card
+}
+
+// Resolve every card in a collection or deck, returning a new slice.
+func (c *CardCatalogue) ResolveAll(cards []Card) []Card {
+	resolved := make([]Card, len(cards))
+
+	for i, card := range cards {
+		resolved[i] = c.Resolve(card)
+	}
+
+	return resolved
+}
+
+// Look up the full card for a player's favourite card.
+func (c *CardCatalogue) ResolveFavourite(favourite FavouriteCard) (Card, bool) {
+	if card, ok := c.ByID(favourite.ID); ok {
+		return card, true
+	}
+
+	return c.ByName(favourite.Name)
+}
+
+// Average elixir cost of a deck, resolving cards against the catalogue where their cost is unknown.
+func (c *CardCatalogue) AverageElixir(deck []Card) float64 {
+	if len(deck) == 0 {
+		return 0
+	}
+
+	total := 0
+
+	for _, card := range deck {
+		if card.ElixirCost == 0 {
+			card = c.Resolve(card)
+		}
+
+		total += card.ElixirCost
+	}
+
+	return float64(total) / float64(len(deck))
+}
+
+type CardsService struct {
+	c *Client
+}
+
+func (c *Client) Cards() *CardsService {
+	return &CardsService{c}
+}
+
+// Get list of all available cards.
+func (i *CardsService) All() (CardList, error) {
+	return i.AllContext(context.Background())
+}
+
+// Like All, but bound to ctx.
+func (i *CardsService) AllContext(ctx context.Context) (CardList, error) {
+	req, err := i.c.NewRequestWithContext(ctx, "GET", "/v1/cards", nil)
+	var cards CardList
+
+	if err == nil {
+		_, err = i.c.Do(req, &cards)
+	}
+
+	return cards, err
+}
+
+// Fetch every card and build a catalogue from them.
+func (i *CardsService) Catalogue() (*CardCatalogue, error) {
+	return i.CatalogueContext(context.Background())
+}
+
+// Like Catalogue, but bound to ctx.
+func (i *CardsService) CatalogueContext(ctx context.Context) (*CardCatalogue, error) {
+	cards, err := i.AllContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCardCatalogue(cards), nil
+}
@@ -0,0 +1,49 @@
+package clash_test
+
+import (
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var catalogue = clash.NewCardCatalogue(clash.CardList{
+	Items: []clash.Card{
+		{ID: 26000000, Name: "Knight", Rarity: "common", ElixirCost: 3, MaxLevel: 14, MaxEvolutionLevel: 1},
+		{ID: 28000000, Name: "Fireball", Rarity: "rare", ElixirCost: 4, MaxLevel: 12},
+	},
+	SupportItems: []clash.Card{
+		{ID: 159000000, Name: "Tower Princess", Rarity: "common", MaxLevel: 14},
+	},
+})
+
+func TestCardCatalogue_Lookup(t *testing.T) {
+	assert.Equal(t, 3, catalogue.Len())
+
+	card, ok := catalogue.ByID(28000000)
+	assert.True(t, ok)
+	assert.Equal(t, "Fireball", card.Name)
+
+	card, ok = catalogue.ByName("knight")
+	assert.True(t, ok)
+	assert.True(t, card.CanEvolve())
+
+	_, ok = catalogue.ByName("Mirror")
+	assert.False(t, ok)
+}
+
+func TestCardCatalogue_Resolve(t *testing.T) {
+	owned := clash.Card{Name: "Knight", Level: 11, Count: 40}
+	resolved := catalogue.Resolve(owned)
+
+	assert.Equal(t, 26000000, resolved.ID)
+	assert.Equal(t, "common", resolved.Rarity)
+	assert.Equal(t, 3, resolved.ElixirCost)
+	assert.Equal(t, 11, resolved.Level)
+	assert.Equal(t, 40, resolved.Count)
+
+	fav, ok := catalogue.ResolveFavourite(clash.FavouriteCard{ID: 28000000})
+	assert.True(t, ok)
+	assert.Equal(t, "Fireball", fav.Name)
+
+	assert.Equal(t, 3.5, catalogue.AverageElixir([]clash.Card{{Name: "Knight"}, {ID: 28000000}}))
+}
@@ -0,0 +1,68 @@
+package clash
+
+import (
+	"context"
+	"time"
+)
+
+type ChallengePrize struct {
+	Type           string `json:"type"`
+	Amount         int    `json:"amount"`
+	Rarity         string `json:"rarity,omitempty"`
+	Resource       string `json:"resource,omitempty"`
+	ConsumableName string `json:"consumableName,omitempty"`
+}
+
+type Challenge struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	WinMode     string           `json:"winMode"`
+	Casual      bool             `json:"casual"`
+	MaxLosses   int              `json:"maxLosses"`
+	MaxWins     int              `json:"maxWins"`
+	IconUrl     string           `json:"iconUrl"`
+	GameMode    GameMode         `json:"gameMode"`
+	Prizes      []ChallengePrize `json:"prizes"`
+}
+
+// A group of challenges which run together, e.g. a multi-stage event.
+type ChallengeChain struct {
+	Type       string      `json:"type"`
+	Title      string      `json:"title"`
+	StartTime  Time        `json:"startTime"`
+	EndTime    Time        `json:"endTime"`
+	Challenges []Challenge `json:"challenges"`
+}
+
+// Whether the challenges are running at the given time.
+func (c *ChallengeChain) ActiveAt(at time.Time) bool {
+	return !at.Before(c.StartTime.Time) && at.Before(c.EndTime.Time)
+}
+
+type ChallengeChains []ChallengeChain
+
+type ChallengesService struct {
+	c *Client
+}
+
+func (c *Client) Challenges() *ChallengesService {
+	return &ChallengesService{c}
+}
+
+// Get list of current and upcoming challenges.
+func (i *ChallengesService) All() (ChallengeChains, error) {
+	return i.AllContext(context.Background())
+}
+
+// Like All, but bound to ctx.
+func (i *ChallengesService) AllContext(ctx context.Context) (ChallengeChains, error) {
+	req, err := i.c.NewRequestWithContext(ctx, "GET", "/v1/challenges", nil)
+	var chains ChallengeChains
+
+	if err == nil {
+		_, err = i.c.Do(req, &chains)
+	}
+
+	return chains, err
+}
@@ -0,0 +1,44 @@
+package clash_test
+
+import (
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChallengesService_All(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`[{
+			"type": "singleChallenge",
+			"title": "Mega Draft",
+			"startTime": "20230612T090000.000Z",
+			"endTime": "20230619T090000.000Z",
+			"challenges": [{"id": 65000001, "name": "Mega Draft", "maxLosses": 3, "maxWins": 12,
+				"gameMode": {"id": 72000009, "name": "Draft"},
+				"prizes": [{"type": "resource", "amount": 500, "resource": "gold"}]}]
+		}]`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	chains, err := client.Challenges().All()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/challenges", path)
+	assert.Equal(t, 1, len(chains))
+
+	chain := chains[0]
+	assert.Equal(t, "Mega Draft", chain.Title)
+	assert.Equal(t, 12, chain.Challenges[0].MaxWins)
+	assert.Equal(t, "gold", chain.Challenges[0].Prizes[0].Resource)
+
+	assert.True(t, chain.ActiveAt(time.Date(2023, 6, 12, 9, 0, 0, 0, time.UTC)))
+	assert.True(t, chain.ActiveAt(time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, chain.ActiveAt(time.Date(2023, 6, 19, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, chain.ActiveAt(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+}
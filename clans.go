@@ -1,48 +1,78 @@
 package clash
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"iter"
 )
 
 type ClanQuery struct {
 	PagedQuery
-	LocationId int
-	MinScore   int
-	MinMembers int
-	MaxMembers int
-	Name       string
+	LocationId int    `url:"locationId,omitempty"`
+	MinScore   int    `url:"minScore,omitempty"`
+	MinMembers int    `url:"minMembers,omitempty"`
+	MaxMembers int    `url:"maxMembers,omitempty"`
+	Name       string `url:"name,omitempty"`
+}
+
+// Check the query against the API's constraints: at least one filter must be set, names must be at least
+// three characters long, and member counts must lie within 2-50 (minimum) and 1-50 (maximum).
+func (q *ClanQuery) Validate() error {
+	if q.LocationId == 0 && q.MinScore == 0 && q.MinMembers == 0 && q.MaxMembers == 0 && q.Name == "" {
+		return &QueryError{"", "at least one filter must be set"}
+	}
+
+	if q.Name != "" && len([]rune(q.Name)) < 3 {
+		return &QueryError{"name", "must be at least 3 characters long"}
+	}
+
+	if q.LocationId < 0 {
+		return &QueryError{"locationId", "must not be negative"}
+	}
+
+	if q.MinScore < 0 {
+		return &QueryError{"minScore", "must not be negative"}
+	}
+
+	// Yes, what you're reading is correct, minMembers needs to be >= 2
+	if q.MinMembers != 0 && (q.MinMembers < 2 || q.MinMembers > 50) {
+		return &QueryError{"minMembers", "must be between 2 and 50"}
+	}
+
+	if q.MaxMembers != 0 && (q.MaxMembers < 1 || q.MaxMembers > 50) {
+		return &QueryError{"maxMembers", "must be between 1 and 50"}
+	}
+
+	if q.MinMembers != 0 && q.MaxMembers != 0 && q.MinMembers > q.MaxMembers {
+		return &QueryError{"minMembers", "must not be greater than maxMembers"}
+	}
+
+	return q.PagedQuery.Validate()
 }
 
 type Clan struct {
-	Tag               string       `json:"tag"`
-	Name              string       `json:"name"`
-	Type              string       `json:"type"`
-	Description       string       `json:"description"`
-	BadgeId           int          `json:"badgeId"`
-	ClanScore         int          `json:"clanScore"`
-	ClanWarTrophies   int          `json:"clanWarTrophies"`
-	Location          Location     `json:"location"`
-	RequiredTrophies  int          `json:"requiredTrophies"`
-	DonationsPerWeek  int          `json:"donationsPerWeek"`
-	ClanChestStatus   string       `json:"clanChestStatus"`
-	ClanChestPoints   int          `json:"clanChestPoints"`
-	ClanChestLevel    int          `json:"clanChestLevel"`
-	ClanChestMaxLevel int          `json:"clanChestMaxLevel"`
-	Members           int          `json:"members"`
-	MemberList        []ClanMember `json:"memberList"`
-}
-
-type ClanPager struct {
-	Items  []Clan `json:"items"`
-	Paging Paging `json:"paging"`
-}
-
-type MemberPager struct {
-	Items  []ClanMember `json:"items"`
-	Paging Paging       `json:"paging"`
+	Tag               string          `json:"tag"`
+	Name              string          `json:"name"`
+	Type              ClanType        `json:"type"`
+	Description       string          `json:"description"`
+	BadgeId           int             `json:"badgeId"`
+	ClanScore         int             `json:"clanScore"`
+	ClanWarTrophies   int             `json:"clanWarTrophies"`
+	Location          Location        `json:"location"`
+	RequiredTrophies  int             `json:"requiredTrophies"`
+	DonationsPerWeek  int             `json:"donationsPerWeek"`
+	ClanChestStatus   ClanChestStatus `json:"clanChestStatus"`
+	ClanChestPoints   int             `json:"clanChestPoints"`
+	ClanChestLevel    int             `json:"clanChestLevel"`
+	ClanChestMaxLevel int             `json:"clanChestMaxLevel"`
+	Members           int             `json:"members"`
+	MemberList        []ClanMember    `json:"memberList"`
 }
 
+type ClanPager = Page[Clan]
+
+type MemberPager = Page[ClanMember]
+
 type WarParticipant struct {
 	Tag                        string `json:"tag"`
 	Name                       string `json:"name"`
@@ -69,45 +99,27 @@ type WarStanding struct {
 }
 
 type War struct {
-	SeasonId       int              `json:"seasonId"`
-	RawCreatedDate string           `json:"createdDate"`
-	Participants   []WarParticipant `json:"participants"`
-	Standings      []WarStanding    `json:"standings"`
-}
-
-func (w *War) CreatedDate() time.Time {
-	parsed, _ := time.Parse(TimeLayout, w.RawCreatedDate)
-	return parsed
+	SeasonId     int              `json:"seasonId"`
+	CreatedDate  Time             `json:"createdDate"`
+	Participants []WarParticipant `json:"participants"`
+	Standings    []WarStanding    `json:"standings"`
 }
 
-type WarLogPager struct {
-	Items  []War  `json:"items"`
-	Paging Paging `json:"paging"`
-}
+type WarLogPager = Page[War]
 
 type CurrentWar struct {
-	State                string           `json:"state"`
-	RawCollectionEndTime string           `json:"collectionEndTime"`
-	Clan                 WarClanDetails   `json:"clan"`
-	Clans                []WarClanDetails `json:"clans"`
-	Participants         []WarParticipant `json:"participants"`
-	RawWarEndTime        string           `json:"warEndTime"`
-}
-
-func (w *CurrentWar) CollectionEndTime() time.Time {
-	parsed, _ := time.Parse(TimeLayout, w.RawCollectionEndTime)
-	return parsed
-}
-
-func (w *CurrentWar) WarEndTime() time.Time {
-	parsed, _ := time.Parse(TimeLayout, w.RawWarEndTime)
-	return parsed
+	State             WarState         `json:"state"`
+	CollectionEndTime Time             `json:"collectionEndTime"`
+	Clan              WarClanDetails   `json:"clan"`
+	Clans             []WarClanDetails `json:"clans"`
+	Participants      []WarParticipant `json:"participants"`
+	WarEndTime        Time             `json:"warEndTime"`
 }
 
 type ClanMember struct {
 	Tag               string `json:"tag"`
 	Name              string `json:"name"`
-	Role              string `json:"role"`
+	Role              Role   `json:"role"`
 	ExpLevel          int    `json:"expLevel"`
 	Trophies          int    `json:"trophies"`
 	Arena             Arena  `json:"arena"`
@@ -116,12 +128,7 @@ type ClanMember struct {
 	Donations         int    `json:"donations"`
 	DonationsReceived int    `json:"donationsReceived"`
 	ClanChestPoints   int    `json:"clanChestPoints"`
-	RawLastSeen       string `json:"lastSeen"`
-}
-
-func (c *ClanMember) LastSeen() time.Time {
-	parsed, _ := time.Parse(TimeLayout, c.RawLastSeen)
-	return parsed
+	LastSeen          Time   `json:"lastSeen"`
 }
 
 type ClansService struct {
@@ -144,8 +151,13 @@ func (c *Client) Clan(tag string) *ClanService {
 // Get information about a single clan by clan tag.
 // Clan tags can be found using clan search operation.
 func (i *ClanService) Get() (Clan, error) {
+	return i.GetContext(context.Background())
+}
+
+// Like Get, but bound to ctx.
+func (i *ClanService) GetContext(ctx context.Context) (Clan, error) {
 	url := fmt.Sprintf("/v1/clans/%s", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.NewRequestWithContext(ctx, "GET", url, nil)
 	var clan Clan
 
 	if err == nil {
@@ -156,9 +168,16 @@ func (i *ClanService) Get() (Clan, error) {
 }
 
 // Retrieve information about clan's current clan war
+//
+// Deprecated: clan wars were replaced by river races; use CurrentRiverRace.
 func (i *ClanService) CurrentWar() (CurrentWar, error) {
+	return i.CurrentWarContext(context.Background())
+}
+
+// Like CurrentWar, but bound to ctx.
+func (i *ClanService) CurrentWarContext(ctx context.Context) (CurrentWar, error) {
 	url := fmt.Sprintf("/v1/clans/%s/currentwar", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.NewRequestWithContext(ctx, "GET", url, nil)
 	var war CurrentWar
 
 	if err == nil {
@@ -168,80 +187,111 @@ func (i *ClanService) CurrentWar() (CurrentWar, error) {
 	return war, err
 }
 
-// Retrieve clan's clan war log
-func (i *ClanService) WarLog() (WarLogPager, error) {
+// Retrieve clan's clan war log. query may be nil to fetch the first page with the default size.
+//
+// Deprecated: clan wars were replaced by river races; use RiverRaceLog.
+func (i *ClanService) WarLog(query *PagedQuery) (WarLogPager, error) {
+	return i.WarLogContext(context.Background(), query)
+}
+
+// Like WarLog, but bound to ctx.
+func (i *ClanService) WarLogContext(ctx context.Context, query *PagedQuery) (WarLogPager, error) {
 	url := fmt.Sprintf("/v1/clans/%s/warlog", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.newQueryRequest(ctx, url, query)
 	var warLog WarLogPager
 
 	if err == nil {
 		_, err = i.c.Do(req, &warLog)
 	}
 
+	warLog.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (WarLogPager, error) {
+		return i.WarLogContext(ctx, &page)
+	})
+
 	return warLog, err
 }
 
-// List clan members
-func (i *ClanService) Members() (MemberPager, error) {
+// Iterate over every war in the clan's war log, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems wars unless it is zero.
+func (i *ClanService) WarLogIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[War, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]War, Paging, error) {
+		warLog, err := i.WarLogContext(ctx, page)
+		return warLog.Items, warLog.Paging, err
+	})
+}
+
+// List clan members. query may be nil to fetch the first page with the default size.
+func (i *ClanService) Members(query *PagedQuery) (MemberPager, error) {
+	return i.MembersContext(context.Background(), query)
+}
+
+// Like Members, but bound to ctx.
+func (i *ClanService) MembersContext(ctx context.Context, query *PagedQuery) (MemberPager, error) {
 	url := fmt.Sprintf("/v1/clans/%s/members", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.newQueryRequest(ctx, url, query)
 	var members MemberPager
 
 	if err == nil {
 		_, err = i.c.Do(req, &members)
 	}
 
+	members.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (MemberPager, error) {
+		return i.MembersContext(ctx, &page)
+	})
+
 	return members, err
 }
 
+// Iterate over every clan member, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems members unless it is zero.
+func (i *ClanService) MembersIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[ClanMember, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]ClanMember, Paging, error) {
+		members, err := i.MembersContext(ctx, page)
+		return members.Items, members.Paging, err
+	})
+}
+
 // Search all clans by name and/or filtering the results using various criteria.
 // At least one filtering criteria must be defined and if name is used
 // as part of search, it is required to be at least three characters long.
+// Queries breaking these rules are reported as a *QueryError without making a request.
 func (i *ClansService) Search(query *ClanQuery) (ClanPager, error) {
-	req, err := i.c.NewRequest("GET", "/v1/clans", nil)
-	q := req.URL.Query()
-
-	if query.LocationId > 0 {
-		q.Add("locationId", fmt.Sprintf("%d", query.LocationId))
-	}
-
-	if query.MinScore > 0 {
-		q.Add("minScore", fmt.Sprintf("%d", query.MinScore))
-	}
-
-	// Yes, what you're reading is correct, minMembers needs to be >= 2
-	if query.MinMembers >= 2 {
-		q.Add("minMembers", fmt.Sprintf("%d", query.MinMembers))
-	}
-
-	// maxMembers cannot be zero
-	if query.MaxMembers >= 1 && query.MaxMembers <= 50 {
-		q.Add("maxMembers", fmt.Sprintf("%d", query.MaxMembers))
-	}
-
-	if len(query.Name) >= 3 {
-		q.Add("name", query.Name)
-	}
-
-	if query.Limit > 0 {
-		q.Add("limit", fmt.Sprintf("%d", query.Limit))
-	}
-
-	if query.After > 0 {
-		q.Add("after", fmt.Sprintf("%d", query.After))
-	}
+	return i.SearchContext(context.Background(), query)
+}
 
-	if query.Before > 0 {
-		q.Add("before", fmt.Sprintf("%d", query.Before))
+// Like Search, but bound to ctx.
+func (i *ClansService) SearchContext(ctx context.Context, query *ClanQuery) (ClanPager, error) {
+	if query == nil {
+		query = &ClanQuery{}
 	}
 
-	req.URL.RawQuery = q.Encode()
-
+	req, err := i.c.newQueryRequest(ctx, "/v1/clans", query)
 	var clans ClanPager
 
 	if err == nil {
 		_, err = i.c.Do(req, &clans)
 	}
 
+	clans.cursor = newCursor(&query.PagedQuery, func(ctx context.Context, page PagedQuery) (ClanPager, error) {
+		next := *query
+		next.PagedQuery = page
+		return i.SearchContext(ctx, &next)
+	})
+
 	return clans, err
 }
+
+// Iterate over every clan matching the query, fetching pages as needed. The query's Limit sets the page size;
+// iteration stops after maxItems clans unless it is zero.
+func (i *ClansService) SearchIter(ctx context.Context, query *ClanQuery, maxItems int) iter.Seq2[Clan, error] {
+	if query == nil {
+		query = &ClanQuery{}
+	}
+
+	return paginate(ctx, &query.PagedQuery, maxItems, func(ctx context.Context, page *PagedQuery) ([]Clan, Paging, error) {
+		next := *query
+		next.PagedQuery = *page
+		clans, err := i.SearchContext(ctx, &next)
+		return clans.Items, clans.Paging, err
+	})
+}
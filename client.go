@@ -2,13 +2,13 @@ package clash
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"os"
 	"time"
 )
 
@@ -16,63 +16,82 @@ import (
 var TimeLayout = "20060102T150405.000Z"
 
 type Client struct {
-	BaseURL    *url.URL
-	UserAgent  string
-	Bearer     string
-	httpClient http.Client
-	logger     *log.Logger
+	BaseURL   *url.URL
+	UserAgent string
+	Bearer    string
+	// Retry policy for throttled or failed requests. Nil disables retries.
+	Retry *RetryPolicy
+	// Limits the rate of outgoing requests, including retries. Nil means unlimited.
+	Limiter RateLimiter
+	// Opt-in cache for GET responses, honouring Cache-Control and ETag headers. Nil disables caching.
+	Cache Cache
+	// Pool of keys to rotate between. If set, it is used instead of Bearer.
+	// Cached responses are shared between the keys in a pool.
+	Keys       *KeyPool
+	httpClient *http.Client
+	logger     *slog.Logger
+	middleware []Middleware
+	// deferred error from construction, e.g. an unparseable base URL
+	err error
+	// set by WithTransport and WithTimeout, and applied to a copy of httpClient once all options have run
+	transport http.RoundTripper
+	timeout   *time.Duration
 }
 
-// Base struct for paged queries.
-type PagedQuery struct {
-	Limit  int
-	After  int
-	Before int
-}
+// The User-Agent sent unless overridden with WithUserAgent.
+const DefaultUserAgent = "go-clash"
 
-// The error response sent by the API if 4xx/5xx status code.
-type ErrorBody struct {
-	Reason  string `json:"reason"`
-	Message string `json:"message"`
-}
+// Create a client authenticating with the given bearer token, configured by any options given.
+// Nothing is logged unless a logger is supplied with WithLogger.
+func NewClient(token string, opts ...Option) *Client {
+	base, _ := url.Parse("https://api.clashroyale.com")
 
-// APIError implements the error interface.
-type APIError struct {
-	Response *http.Response
-	Body     *ErrorBody
-}
+	client := &Client{
+		Bearer:     token,
+		BaseURL:    base,
+		UserAgent:  DefaultUserAgent,
+		httpClient: &http.Client{},
+		logger:     slog.New(slog.DiscardHandler),
+	}
 
-func (e *APIError) Error() string {
-	return fmt.Sprintf("[%d] %s: %s", e.Response.StatusCode, e.Body.Reason, e.Body.Message)
-}
+	for _, opt := range opts {
+		opt(client)
+	}
 
-// Paging for pager responses. 'before' and 'after' may be empty if there are no more results to return.
-type Paging struct {
-	Cursors struct {
-		Before string `json:"before"`
-		After  string `json:"after"`
-	} `json:"cursors"`
-}
+	if client.transport != nil || client.timeout != nil {
+		hc := *client.httpClient
 
-func NewClient(token string) *Client {
-	base, _ := url.Parse("https://api.clashroyale.com")
-	logger := log.New(os.Stdout, "(go-clash) ", 0)
+		if client.transport != nil {
+			hc.Transport = client.transport
+		}
 
-	client := &Client{
-		Bearer:  token,
-		BaseURL: base,
-		logger:  logger,
+		if client.timeout != nil {
+			hc.Timeout = *client.timeout
+		}
+
+		client.httpClient = &hc
 	}
 
 	return client
 }
 
-func (c *Client) SetTimeout (duration time.Duration) {
+// Set the timeout of the underlying http.Client. Prefer WithTimeout when constructing the client,
+// as this modifies any http.Client supplied with WithHTTPClient.
+func (c *Client) SetTimeout(duration time.Duration) {
 	c.httpClient.Timeout = duration
 }
 
 // make a new request object.
 func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, path, body)
+}
+
+// make a new request object bound to ctx. Cancelling the context aborts the request in Do.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
 	rel := &url.URL{Path: path}
 	u := c.BaseURL.ResolveReference(rel)
 	var buf io.ReadWriter
@@ -84,7 +103,7 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 
 	if err != nil {
 		return nil, err
@@ -100,32 +119,161 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 	return req, nil
 }
 
-// execute the request.
+// execute the request, retrying according to the client's retry policy.
+// GET requests are served from the client's cache if a fresh entry exists.
+// If the request's context carries a ResponseMeta (see WithResponseMeta) it is filled in, even if the request fails.
 func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	c.logger.Println(req.Method, req.URL.String())
-	resp, err := c.httpClient.Do(req)
+	start := time.Now()
+	recorder, _ := req.Context().Value(metaKey{}).(*metaRecorder)
+	recorder.reset()
 
-	if err != nil {
-		c.logger.Println("Request error", err.Error())
-		return nil, err
+	resp, err := c.do(req, v)
+	recorder.record(resp, start)
+
+	return resp, err
+}
+
+func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
+	var key string
+	var cached *CacheEntry
+
+	if c.Cache != nil && req.Method == "GET" {
+		key = cacheKey(req)
+
+		if entry, ok := c.Cache.Get(key); ok {
+			if entry.Fresh() {
+				c.logger.DebugContext(req.Context(), "clash: request served from cache",
+					"method", req.Method, "path", req.URL.Path, "status", entry.StatusCode, "from_cache", true)
+				return entry.response(req, FromCacheHeader), decode(entry.Body, v)
+			}
+
+			cached = entry
+
+			if entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+		}
 	}
 
-	defer resp.Body.Close()
+	rotations := 0
+
+	for attempt, sent := 1, false; ; attempt, sent = attempt+1, true {
+		if sent && req.GetBody != nil {
+			body, err := req.GetBody()
 
-	if resp.StatusCode >= 400 {
-		c.logger.Println("Unexpected status code", resp.StatusCode)
+			if err != nil {
+				return nil, err
+			}
 
-		errorResponse := &ErrorBody{}
-		err = json.NewDecoder(resp.Body).Decode(errorResponse)
+			req.Body = body
+		}
 
-		if err == nil {
-			err = &APIError{resp, errorResponse}
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		var token string
+
+		if c.Keys != nil {
+			token = c.Keys.pick()
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		start := time.Now()
+		resp, err := c.doer().Do(req)
+		duration := time.Since(start)
+
+		if err != nil {
+			c.logger.ErrorContext(req.Context(), "clash: request failed",
+				"method", req.Method, "path", req.URL.Path, "attempt", attempt, "duration", duration, "error", err)
+			return nil, err
+		}
+
+		c.logger.DebugContext(req.Context(), "clash: request",
+			"method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "attempt", attempt, "duration", duration)
+
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			resp.Body.Close()
+
+			// the entry may be shared with concurrent readers, so store an updated copy instead of mutating it
+			if expires, ok := cacheExpiry(resp.Header); ok {
+				updated := *cached
+				updated.Expires = expires
+				c.Cache.Set(key, &updated)
+			}
+
+			return cached.response(req, RevalidatedHeader), decode(cached.Body, v)
+		}
+
+		if resp.StatusCode < 400 {
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if err != nil {
+				return resp, err
+			}
+
+			if key != "" {
+				if expires, ok := cacheExpiry(resp.Header); ok {
+					c.Cache.Set(key, &CacheEntry{
+						StatusCode: resp.StatusCode,
+						Header:     resp.Header.Clone(),
+						Body:       body,
+						ETag:       resp.Header.Get("ETag"),
+						Expires:    expires,
+					})
+				}
+			}
+
+			return resp, decode(body, v)
+		}
+
+		// a partially read body still makes a useful error, so read failures are ignored
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		resp.Body.Close()
+
+		apiErr := newAPIError(resp, raw)
+		errorResponse := apiErr.Body
+		err = apiErr
+
+		// a refused key is benched and the request immediately retried on another, without counting as an attempt
+		if c.Keys != nil && keyRejected(resp.StatusCode, errorResponse.Reason) {
+			c.Keys.Bench(token)
+
+			if rotations < c.Keys.Len()-1 && c.Keys.available() && (req.Body == nil || req.GetBody != nil) {
+				c.logger.WarnContext(req.Context(), "clash: key rejected, rotating",
+					"method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "reason", errorResponse.Reason)
+				rotations++
+				attempt--
+				continue
+			}
+		}
+
+		if !c.Retry.shouldRetry(req, resp, errorResponse.Reason, attempt) {
+			c.logger.WarnContext(req.Context(), "clash: unexpected status code",
+				"method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "reason", errorResponse.Reason, "attempt", attempt)
+			return resp, err
+		}
+
+		delay := c.Retry.backoff(resp, attempt)
+		c.logger.InfoContext(req.Context(), "clash: retrying request",
+			"method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "attempt", attempt, "delay", delay)
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return resp, err
 		}
-	} else {
-		err = json.NewDecoder(resp.Body).Decode(v)
 	}
+}
 
-	return resp, err
+// decode a response body into v. Empty bodies (e.g. 204 No Content) and a nil v are not errors.
+func decode(body []byte, v interface{}) error {
+	if v == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, v)
 }
 
 // make sure the tag is prefixed with a # if it doesn't have one
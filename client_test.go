@@ -1,8 +1,17 @@
 package clash_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"github.com/fiskie/go-clash"
 	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
 	"testing"
 	"time"
 )
@@ -12,3 +21,369 @@ func TestTimeParsing(t *testing.T) {
 	tm, _ := time.Parse(clash.TimeLayout, "20180712T110230.000Z")
 	assert.Equal(t, int64(1531393350), tm.Unix())
 }
+
+// a cancelled context should abort the request before it reaches the server.
+func TestCancelledContext(t *testing.T) {
+	hit := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token")
+	client.BaseURL, _ = url.Parse(srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Player("#111").GetContext(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, hit)
+}
+
+// throttled requests should be retried, honouring the policy's attempt limit.
+func TestRetryPolicy(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"reason":"requestThrottled","message":"slow down"}`))
+			return
+		}
+		w.Write([]byte(`{"tag":"#111","name":"fisk"}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token")
+	client.BaseURL, _ = url.Parse(srv.URL)
+	client.Retry = clash.DefaultRetryPolicy()
+	client.Retry.BaseDelay = time.Millisecond
+
+	player, err := client.Player("#111").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "fisk", player.Name)
+	assert.Equal(t, 3, hits)
+
+	// POSTs are not retried unless explicitly allowed
+	hits = 0
+	_, err = client.Player("#111").VerifyToken("abc")
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, hits)
+
+	// a zero base delay retries immediately instead of falling back to MaxDelay
+	hits = 0
+	client.Retry.BaseDelay = 0
+	start := time.Now()
+	_, err = client.Player("#111").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, hits)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+// fresh responses should be served from the cache; stale ones revalidated with their ETag.
+func TestResponseCache(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", r.URL.Query().Get("cc"))
+		w.Write([]byte(`{"id":57000000,"name":"Europe"}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token")
+	client.BaseURL, _ = url.Parse(srv.URL)
+	client.Cache = clash.NewLRUCache(10)
+
+	get := func(cc string) (clash.Location, *http.Response) {
+		req, _ := client.NewRequest("GET", "/v1/locations/57000000", nil)
+		req.URL.RawQuery = "cc=" + cc
+		var location clash.Location
+		resp, err := client.Do(req, &location)
+		assert.Nil(t, err)
+		return location, resp
+	}
+
+	_, resp := get("max-age=60")
+	assert.False(t, clash.FromCache(resp))
+	location, resp := get("max-age=60")
+	assert.True(t, clash.FromCache(resp))
+	assert.False(t, clash.Revalidated(resp))
+	assert.Equal(t, "Europe", location.Name)
+	assert.Equal(t, 1, hits)
+
+	// no-cache entries are always revalidated, which takes a round trip
+	get("no-cache")
+	location, resp = get("no-cache")
+	assert.False(t, clash.FromCache(resp))
+	assert.True(t, clash.Revalidated(resp))
+	assert.Equal(t, "Europe", location.Name)
+	assert.Equal(t, 3, hits)
+}
+
+// concurrent revalidations of the same entry must not race; run with -race.
+func TestResponseCacheConcurrentRevalidation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"id":57000000,"name":"Europe"}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token")
+	client.BaseURL, _ = url.Parse(srv.URL)
+	client.Cache = clash.NewLRUCache(10)
+
+	_, err := client.Location("57000000").Get()
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				location, err := client.Location("57000000").Get()
+				assert.Nil(t, err)
+				assert.Equal(t, "Europe", location.Name)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// a key refused by the API should be benched and the request retried with the next key.
+func TestKeyPoolRotation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer bad" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"reason":"accessDenied.invalidIp","message":"Invalid authorization"}`))
+			return
+		}
+		w.Write([]byte(`{"tag":"#111","name":"fisk"}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("")
+	client.BaseURL, _ = url.Parse(srv.URL)
+	client.Keys = clash.NewKeyPool("bad", "good")
+
+	for i := 0; i < 3; i++ {
+		player, err := client.Player("#111").Get()
+		assert.Nil(t, err)
+		assert.Equal(t, "fisk", player.Name)
+	}
+
+	usage := client.Keys.Usage()
+	assert.Equal(t, 1, usage[0].Requests)
+	assert.True(t, usage[0].Benched())
+	assert.Equal(t, 3, usage[1].Requests)
+}
+
+// requests should be logged with structured fields when a logger is supplied.
+func TestWithLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":57000000,"name":"Europe"}`))
+	}))
+	defer srv.Close()
+
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := clash.NewClient("token", clash.WithLogger(logger))
+	client.BaseURL, _ = url.Parse(srv.URL)
+
+	_, err := client.Location("57000000").Get()
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "method=GET path=/v1/locations/57000000 status=200 attempt=1")
+}
+
+func TestClientOptions(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"id":57000000,"name":"Europe"}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL), clash.WithUserAgent("my-bot/1.0"), clash.WithTimeout(time.Second))
+	_, err := client.Location("57000000").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "my-bot/1.0", userAgent)
+
+	client = clash.NewClient("token", clash.WithBaseURL("://nope"))
+	_, err = client.Location("57000000").Get()
+	assert.NotNil(t, err)
+
+	// timeout and transport survive a later WithHTTPClient, without modifying the caller's client
+	trips := 0
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		trips++
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	httpClient := &http.Client{}
+	client = clash.NewClient("token", clash.WithBaseURL(srv.URL), clash.WithTimeout(time.Nanosecond),
+		clash.WithTransport(transport), clash.WithHTTPClient(httpClient))
+	_, err = client.Location("57000000").Get()
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, trips)
+	assert.Equal(t, time.Duration(0), httpClient.Timeout)
+	assert.Nil(t, httpClient.Transport)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// middleware should wrap every request, in the order it was added.
+func TestMiddleware(t *testing.T) {
+	var trace string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trace = r.Header.Get("X-Trace")
+		w.Write([]byte(`{"id":57000000,"name":"Europe"}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	var timed time.Duration
+	tag := func(name string) clash.Middleware {
+		return func(next clash.Doer) clash.Doer {
+			return clash.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL), clash.WithMiddleware(tag("outer"), tag("inner")))
+	client.Use(
+		clash.HeaderMiddleware(http.Header{"X-Trace": {"abc"}}),
+		clash.TimingMiddleware(func(req *http.Request, resp *http.Response, d time.Duration, err error) {
+			timed = d
+		}),
+	)
+
+	_, err := client.Location("57000000").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, "abc", trace)
+	assert.True(t, timed > 0)
+}
+
+// non-JSON error bodies should still produce an APIError, and empty successful bodies are not an error.
+func TestUnstructuredResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/locations/1":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>Bad Gateway</html>"))
+		case "/v1/locations/2":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	_, err := client.Location("1").Get()
+	var apiErr *clash.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, "<html>Bad Gateway</html>", string(apiErr.RawBody))
+	assert.Equal(t, "text/html", apiErr.Header.Get("Content-Type"))
+	assert.Equal(t, "GET", apiErr.Method)
+	assert.Equal(t, "[502] Bad Gateway", err.Error())
+	assert.True(t, clash.IsTemporary(err))
+
+	_, err = client.Location("2").Get()
+	assert.Nil(t, err)
+}
+
+// response metadata should be recorded into a context carrying a ResponseMeta.
+func TestWithResponseMeta(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(`{"tag":"#222","name":"Clan"}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL), clash.WithCache(clash.NewLRUCache(10)))
+
+	var meta clash.ResponseMeta
+	ctx := clash.WithResponseMeta(context.Background(), &meta)
+
+	_, err := client.Clan("#222").GetContext(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.Equal(t, "max-age=60", meta.Header.Get("Cache-Control"))
+	assert.False(t, meta.FromCache)
+	assert.False(t, meta.FetchedAt.IsZero())
+
+	_, err = client.Clan("#222").GetContext(ctx)
+	assert.Nil(t, err)
+	assert.True(t, meta.FromCache)
+
+	// calls sharing the context concurrently must not race; run with -race
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Clan("#222").GetContext(ctx)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+
+	// a failed call doesn't leave the previous call's metadata behind
+	srv.Close()
+	client.Cache = nil
+	_, err = client.Clan("#222").GetContext(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, meta.StatusCode)
+	assert.Nil(t, meta.Header)
+	assert.False(t, meta.FromCache)
+}
+
+// timestamps should decode into clash.Time, keeping the raw value, and reject malformed input.
+func TestTimeDecoding(t *testing.T) {
+	var battle clash.Battle
+	assert.Nil(t, json.Unmarshal([]byte(`{"battleTime":"20180712T110230.000Z"}`), &battle))
+	assert.Equal(t, int64(1531393350), battle.BattleTime.Unix())
+	assert.Equal(t, "20180712T110230.000Z", battle.BattleTime.Raw)
+
+	encoded, _ := json.Marshal(battle.BattleTime)
+	assert.Equal(t, `"20180712T110230.000Z"`, string(encoded))
+
+	var member clash.ClanMember
+	assert.Nil(t, json.Unmarshal([]byte(`{"tag":"#111"}`), &member))
+	assert.True(t, member.LastSeen.IsZero())
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"lastSeen":"yesterday"}`), &member))
+
+	// changes to the time replace the timestamp the API sent
+	changed := battle.BattleTime
+	changed.Time = changed.Add(time.Hour)
+	encoded, _ = json.Marshal(changed)
+	assert.Equal(t, `"20180712T120230.000Z"`, string(encoded))
+
+	// text encodings, such as map keys, use the API's layout too
+	encoded, _ = json.Marshal(map[clash.Time]int{battle.BattleTime: 1})
+	assert.Equal(t, `{"20180712T110230.000Z":1}`, string(encoded))
+
+	var counts map[clash.Time]int
+	assert.Nil(t, json.Unmarshal(encoded, &counts))
+	assert.Equal(t, 1, counts[battle.BattleTime])
+}
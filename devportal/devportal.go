@@ -0,0 +1,296 @@
+// Package devportal manages API keys on the Clash Royale developer portal (developer.clashroyale.com).
+//
+// API keys are bound to the IP addresses they were created for, so machines with changing addresses need
+// a fresh key whenever they move. This package logs in with the developer account's credentials, creates
+// a key for the current public IP if needed, and cleans up the keys it created for previous addresses.
+package devportal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/fiskie/go-clash"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+)
+
+// The portal refuses to hold more than this many keys per account.
+const MaxKeys = 10
+
+// An API key registered on the developer portal.
+type Key struct {
+	ID          string   `json:"id"`
+	DeveloperID string   `json:"developerId"`
+	Tier        string   `json:"tier"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Scopes      []string `json:"scopes"`
+	CidrRanges  []string `json:"cidrRanges"`
+	Key         string   `json:"key"`
+}
+
+// Whether the key may be used from the given IP address.
+func (k *Key) AllowsIP(ip string) bool {
+	for _, cidr := range k.CidrRanges {
+		if cidr == ip || cidr == ip+"/32" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The status block included in every portal response.
+type Status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+}
+
+// Error returned when the portal reports a non-zero status, e.g. for bad credentials.
+type Error struct {
+	StatusCode int
+	Status     Status
+}
+
+func (e *Error) Error() string {
+	if e.Status.Detail != "" {
+		return fmt.Sprintf("devportal: [%d] %s: %s", e.StatusCode, e.Status.Message, e.Status.Detail)
+	}
+
+	return fmt.Sprintf("devportal: [%d] %s", e.StatusCode, e.Status.Message)
+}
+
+// Returned by operations which need a session when Login hasn't succeeded yet.
+var ErrNotLoggedIn = errors.New("devportal: not logged in")
+
+// A session with the developer portal.
+type Portal struct {
+	// Portal base URL, overridable to point at a stand-in server.
+	BaseURL *url.URL
+	// URL of a service which responds with the caller's public IP address as plain text.
+	IPLookupURL string
+	// Scopes granted to keys created by this portal session.
+	Scopes []string
+
+	httpClient *http.Client
+	loggedIn   bool
+}
+
+// Create a portal session. The http client, if given, is copied so the session can keep its own cookie jar.
+func New(httpClient *http.Client) *Portal {
+	var hc http.Client
+
+	if httpClient != nil {
+		hc = *httpClient
+	}
+
+	hc.Jar, _ = cookiejar.New(nil)
+	base, _ := url.Parse("https://developer.clashroyale.com")
+
+	return &Portal{
+		BaseURL:     base,
+		IPLookupURL: "https://api.ipify.org",
+		Scopes:      []string{"royale"},
+		httpClient:  &hc,
+	}
+}
+
+// POST a JSON body to a portal endpoint and decode the response into v.
+func (p *Portal) post(ctx context.Context, path string, body, v interface{}) error {
+	buf := new(bytes.Buffer)
+
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return err
+	}
+
+	u := p.BaseURL.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), buf)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Status Status `json:"status"`
+	}
+
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Status.Code != 0 || resp.StatusCode >= 400 {
+		if envelope.Status.Message == "" {
+			envelope.Status.Message = http.StatusText(resp.StatusCode)
+		}
+
+		return &Error{resp.StatusCode, envelope.Status}
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// Log in to the portal with the developer account's credentials.
+func (p *Portal) Login(ctx context.Context, email, password string) error {
+	err := p.post(ctx, "/api/login", map[string]string{"email": email, "password": password}, nil)
+	p.loggedIn = err == nil
+	return err
+}
+
+// List every key on the account.
+func (p *Portal) Keys(ctx context.Context) ([]Key, error) {
+	if !p.loggedIn {
+		return nil, ErrNotLoggedIn
+	}
+
+	var result struct {
+		Keys []Key `json:"keys"`
+	}
+
+	err := p.post(ctx, "/api/apikey/list", struct{}{}, &result)
+	return result.Keys, err
+}
+
+// Create a key usable from the given IP addresses.
+func (p *Portal) CreateKey(ctx context.Context, name, description string, ips ...string) (Key, error) {
+	if !p.loggedIn {
+		return Key{}, ErrNotLoggedIn
+	}
+
+	body := map[string]interface{}{
+		"name":        name,
+		"description": description,
+		"cidrRanges":  ips,
+		"scopes":      p.Scopes,
+	}
+
+	var result struct {
+		Key Key `json:"key"`
+	}
+
+	err := p.post(ctx, "/api/apikey/create", body, &result)
+	return result.Key, err
+}
+
+// Delete a key by its ID.
+func (p *Portal) RevokeKey(ctx context.Context, id string) error {
+	if !p.loggedIn {
+		return ErrNotLoggedIn
+	}
+
+	return p.post(ctx, "/api/apikey/revoke", map[string]string{"id": id}, nil)
+}
+
+// Find this machine's public IP address using the portal's IPLookupURL.
+func (p *Portal) PublicIP(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.IPLookupURL, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := p.httpClient.Do(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("devportal: IP lookup failed with status %d", resp.StatusCode)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Return a key named name which is usable from the current public IP, creating one if necessary.
+// Keys with the same name that are bound to other addresses are considered stale and revoked,
+// so name should be unique to the machine or deployment managing its key this way.
+func (p *Portal) EnsureKey(ctx context.Context, name string) (Key, error) {
+	ip, err := p.PublicIP(ctx)
+
+	if err != nil {
+		return Key{}, err
+	}
+
+	keys, err := p.Keys(ctx)
+
+	if err != nil {
+		return Key{}, err
+	}
+
+	remaining := len(keys)
+
+	for _, key := range keys {
+		if key.Name != name {
+			continue
+		}
+
+		if key.AllowsIP(ip) {
+			return key, nil
+		}
+
+		if err := p.RevokeKey(ctx, key.ID); err != nil {
+			return Key{}, err
+		}
+
+		remaining--
+	}
+
+	if remaining >= MaxKeys {
+		return Key{}, fmt.Errorf("devportal: account already has %d keys, revoke one to create a key for %s", remaining, ip)
+	}
+
+	return p.CreateKey(ctx, name, fmt.Sprintf("Created by go-clash for %s", ip), ip)
+}
+
+// Make sure a key named keyName exists for the current public IP and return a client using it,
+// configured with any options given.
+func (p *Portal) NewClient(ctx context.Context, keyName string, opts ...clash.Option) (*clash.Client, error) {
+	key, err := p.EnsureKey(ctx, keyName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clash.NewClient(key.Key, opts...), nil
+}
+
+// Log in to the developer portal and return a client with a key for the current public IP.
+func NewClient(ctx context.Context, email, password, keyName string, opts ...clash.Option) (*clash.Client, error) {
+	portal := New(nil)
+
+	if err := portal.Login(ctx, email, password); err != nil {
+		return nil, err
+	}
+
+	return portal.NewClient(ctx, keyName, opts...)
+}
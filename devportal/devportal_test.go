@@ -0,0 +1,105 @@
+package devportal_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/fiskie/go-clash/devportal"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// a minimal stand-in for the developer portal, holding keys in memory.
+type fakePortal struct {
+	keys    []devportal.Key
+	revoked []string
+}
+
+func (f *fakePortal) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ok := map[string]interface{}{"code": 0, "message": "ok"}
+	var body map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&body)
+
+	if r.URL.Path == "/ip" {
+		w.Write([]byte("203.0.113.7\n"))
+		return
+	}
+
+	if r.URL.Path == "/api/login" {
+		if body["password"] != "hunter2" {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]interface{}{"status": map[string]interface{}{"code": 403, "message": "forbidden"}})
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": ok})
+		return
+	}
+
+	if _, err := r.Cookie("session"); err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/apikey/list":
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": ok, "keys": f.keys})
+	case "/api/apikey/revoke":
+		id := body["id"].(string)
+		f.revoked = append(f.revoked, id)
+		for i, key := range f.keys {
+			if key.ID == id {
+				f.keys = append(f.keys[:i], f.keys[i+1:]...)
+				break
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": ok})
+	case "/api/apikey/create":
+		key := devportal.Key{ID: "new", Name: body["name"].(string), Key: "secret", CidrRanges: []string{"203.0.113.7"}}
+		f.keys = append(f.keys, key)
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": ok, "key": key})
+	}
+}
+
+func TestPortal_EnsureKey(t *testing.T) {
+	fake := &fakePortal{keys: []devportal.Key{
+		{ID: "stale", Name: "ci", CidrRanges: []string{"198.51.100.1"}},
+		{ID: "other", Name: "laptop", CidrRanges: []string{"198.51.100.2"}},
+	}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	portal := devportal.New(nil)
+	portal.BaseURL, _ = url.Parse(srv.URL)
+	portal.IPLookupURL = srv.URL + "/ip"
+	ctx := context.Background()
+
+	_, err := portal.Keys(ctx)
+	assert.Equal(t, devportal.ErrNotLoggedIn, err)
+
+	err = portal.Login(ctx, "dev@example.com", "wrong")
+	_, isPortalError := err.(*devportal.Error)
+	assert.True(t, isPortalError)
+
+	assert.Nil(t, portal.Login(ctx, "dev@example.com", "hunter2"))
+
+	key, err := portal.EnsureKey(ctx, "ci")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", key.Key)
+	assert.Equal(t, []string{"stale"}, fake.revoked)
+
+	// a second call reuses the key for the same address
+	key, err = portal.EnsureKey(ctx, "ci")
+	assert.Nil(t, err)
+	assert.Equal(t, "new", key.ID)
+	assert.Equal(t, 1, len(fake.revoked))
+}
+
+// the caller's http client is left alone; the session keeps its cookies on a copy.
+func TestNew_CopiesClient(t *testing.T) {
+	httpClient := &http.Client{}
+	devportal.New(httpClient)
+	assert.Nil(t, httpClient.Jar)
+}
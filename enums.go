@@ -0,0 +1,293 @@
+package clash
+
+// A player's role within their clan.
+type Role string
+
+const (
+	RoleNotMember Role = "notMember"
+	RoleMember    Role = "member"
+	RoleElder     Role = "elder"
+	RoleCoLeader  Role = "coLeader"
+	RoleLeader    Role = "leader"
+)
+
+func (r Role) String() string {
+	return string(r)
+}
+
+// Seniority of the role, from 0 (not a member, or unknown) to 4 (leader).
+func (r Role) Rank() int {
+	switch r {
+	case RoleMember:
+		return 1
+	case RoleElder:
+		return 2
+	case RoleCoLeader:
+		return 3
+	case RoleLeader:
+		return 4
+	}
+
+	return 0
+}
+
+// Whether the role is more senior than another.
+func (r Role) Outranks(other Role) bool {
+	return r.Rank() > other.Rank()
+}
+
+// Whether the role is one of the known roles.
+func (r Role) Valid() bool {
+	return r == RoleNotMember || r.Rank() > 0
+}
+
+// Who can join a clan.
+type ClanType string
+
+const (
+	ClanTypeOpen       ClanType = "open"
+	ClanTypeInviteOnly ClanType = "inviteOnly"
+	ClanTypeClosed     ClanType = "closed"
+)
+
+func (t ClanType) String() string {
+	return string(t)
+}
+
+func (t ClanType) Valid() bool {
+	switch t {
+	case ClanTypeOpen, ClanTypeInviteOnly, ClanTypeClosed:
+		return true
+	}
+
+	return false
+}
+
+type ClanChestStatus string
+
+const (
+	ClanChestInactive  ClanChestStatus = "inactive"
+	ClanChestActive    ClanChestStatus = "active"
+	ClanChestCompleted ClanChestStatus = "completed"
+	ClanChestUnknown   ClanChestStatus = "unknown"
+)
+
+func (s ClanChestStatus) String() string {
+	return string(s)
+}
+
+func (s ClanChestStatus) Valid() bool {
+	switch s {
+	case ClanChestInactive, ClanChestActive, ClanChestCompleted, ClanChestUnknown:
+		return true
+	}
+
+	return false
+}
+
+// The state of a clan's (legacy) clan war.
+type WarState string
+
+const (
+	WarStateNotInWar      WarState = "notInWar"
+	WarStateMatchmaking   WarState = "matchmaking"
+	WarStateCollectionDay WarState = "collectionDay"
+	WarStateWarDay        WarState = "warDay"
+	WarStateEnded         WarState = "ended"
+)
+
+func (s WarState) String() string {
+	return string(s)
+}
+
+func (s WarState) Valid() bool {
+	switch s {
+	case WarStateNotInWar, WarStateMatchmaking, WarStateCollectionDay, WarStateWarDay, WarStateEnded:
+		return true
+	}
+
+	return false
+}
+
+// Where a clan is in the current river race.
+type RiverRaceState string
+
+const (
+	RiverRaceStateClanNotFound RiverRaceState = "clanNotFound"
+	RiverRaceStateDataNotFound RiverRaceState = "dataNotFound"
+	RiverRaceStateAccessDenied RiverRaceState = "accessDenied"
+	RiverRaceStateNotInWar     RiverRaceState = "notInWar"
+	RiverRaceStateMatchmaking  RiverRaceState = "matchmaking"
+	RiverRaceStateMatched      RiverRaceState = "matched"
+	RiverRaceStateFull         RiverRaceState = "full"
+	RiverRaceStateEnded        RiverRaceState = "ended"
+)
+
+func (s RiverRaceState) String() string {
+	return string(s)
+}
+
+func (s RiverRaceState) Valid() bool {
+	switch s {
+	case RiverRaceStateClanNotFound, RiverRaceStateDataNotFound, RiverRaceStateAccessDenied, RiverRaceStateNotInWar,
+		RiverRaceStateMatchmaking, RiverRaceStateMatched, RiverRaceStateFull, RiverRaceStateEnded:
+		return true
+	}
+
+	return false
+}
+
+// The kind of day (period) a river race is in.
+type RiverRacePeriodType string
+
+const (
+	RiverRacePeriodTraining  RiverRacePeriodType = "training"
+	RiverRacePeriodWarDay    RiverRacePeriodType = "warDay"
+	RiverRacePeriodColosseum RiverRacePeriodType = "colosseum"
+)
+
+func (t RiverRacePeriodType) String() string {
+	return string(t)
+}
+
+func (t RiverRacePeriodType) Valid() bool {
+	switch t {
+	case RiverRacePeriodTraining, RiverRacePeriodWarDay, RiverRacePeriodColosseum:
+		return true
+	}
+
+	return false
+}
+
+type BattleType string
+
+const (
+	BattleTypePvP                    BattleType = "PvP"
+	BattleTypePvE                    BattleType = "PvE"
+	BattleTypeClanMate               BattleType = "clanMate"
+	BattleTypeTournament             BattleType = "tournament"
+	BattleTypeFriendly               BattleType = "friendly"
+	BattleTypeSurvival               BattleType = "survival"
+	BattleTypePvP2v2                 BattleType = "PvP2v2"
+	BattleTypeClanMate2v2            BattleType = "clanMate2v2"
+	BattleTypeChallenge2v2           BattleType = "challenge2v2"
+	BattleTypeClanWarCollectionDay   BattleType = "clanWarCollectionDay"
+	BattleTypeClanWarWarDay          BattleType = "clanWarWarDay"
+	BattleTypeCasual1v1              BattleType = "casual1v1"
+	BattleTypeCasual2v2              BattleType = "casual2v2"
+	BattleTypeBoatBattle             BattleType = "boatBattle"
+	BattleTypeBoatBattlePractice     BattleType = "boatBattlePractice"
+	BattleTypeRiverRacePvP           BattleType = "riverRacePvP"
+	BattleTypeRiverRaceDuel          BattleType = "riverRaceDuel"
+	BattleTypeRiverRaceDuelColosseum BattleType = "riverRaceDuelColosseum"
+	BattleTypeTutorial               BattleType = "tutorial"
+	BattleTypePathOfLegend           BattleType = "pathOfLegend"
+	BattleTypeSeasonalBattle         BattleType = "seasonalBattle"
+	BattleTypePractice               BattleType = "practice"
+	BattleTypeTrail                  BattleType = "trail"
+	BattleTypeUnknown                BattleType = "unknown"
+)
+
+func (t BattleType) String() string {
+	return string(t)
+}
+
+func (t BattleType) Valid() bool {
+	switch t {
+	case BattleTypePvP, BattleTypePvE, BattleTypeClanMate, BattleTypeTournament, BattleTypeFriendly,
+		BattleTypeSurvival, BattleTypePvP2v2, BattleTypeClanMate2v2, BattleTypeChallenge2v2,
+		BattleTypeClanWarCollectionDay, BattleTypeClanWarWarDay, BattleTypeCasual1v1, BattleTypeCasual2v2,
+		BattleTypeBoatBattle, BattleTypeBoatBattlePractice, BattleTypeRiverRacePvP, BattleTypeRiverRaceDuel,
+		BattleTypeRiverRaceDuelColosseum, BattleTypeTutorial, BattleTypePathOfLegend, BattleTypeSeasonalBattle,
+		BattleTypePractice, BattleTypeTrail, BattleTypeUnknown:
+		return true
+	}
+
+	return false
+}
+
+// Whether the battle counted towards a river race (or legacy clan war).
+func (t BattleType) IsClanWar() bool {
+	switch t {
+	case BattleTypeClanWarCollectionDay, BattleTypeClanWarWarDay, BattleTypeBoatBattle,
+		BattleTypeRiverRacePvP, BattleTypeRiverRaceDuel, BattleTypeRiverRaceDuelColosseum:
+		return true
+	}
+
+	return false
+}
+
+// How the players' decks were chosen for a battle.
+type DeckSelection string
+
+const (
+	DeckSelectionCollection       DeckSelection = "collection"
+	DeckSelectionClanWar          DeckSelection = "clanWar"
+	DeckSelectionPredefined       DeckSelection = "predefined"
+	DeckSelectionDraft            DeckSelection = "draft"
+	DeckSelectionDraftCompetitive DeckSelection = "draftCompetitive"
+	DeckSelectionSpecialEvent     DeckSelection = "specialEvent"
+	DeckSelectionEventDeck        DeckSelection = "eventDeck"
+	DeckSelectionPick             DeckSelection = "pick"
+	DeckSelectionWarDeckPick      DeckSelection = "wardeckPick"
+	DeckSelectionQuadDeckPick     DeckSelection = "quaddeckPick"
+	DeckSelectionUnknown          DeckSelection = "unknown"
+)
+
+func (d DeckSelection) String() string {
+	return string(d)
+}
+
+func (d DeckSelection) Valid() bool {
+	switch d {
+	case DeckSelectionCollection, DeckSelectionClanWar, DeckSelectionPredefined, DeckSelectionDraft,
+		DeckSelectionDraftCompetitive, DeckSelectionSpecialEvent, DeckSelectionEventDeck, DeckSelectionPick,
+		DeckSelectionWarDeckPick, DeckSelectionQuadDeckPick, DeckSelectionUnknown:
+		return true
+	}
+
+	return false
+}
+
+type TournamentType string
+
+const (
+	TournamentTypeOpen              TournamentType = "open"
+	TournamentTypePasswordProtected TournamentType = "passwordProtected"
+	TournamentTypeUnknown           TournamentType = "unknown"
+)
+
+func (t TournamentType) String() string {
+	return string(t)
+}
+
+func (t TournamentType) Valid() bool {
+	switch t {
+	case TournamentTypeOpen, TournamentTypePasswordProtected, TournamentTypeUnknown:
+		return true
+	}
+
+	return false
+}
+
+type TournamentStatus string
+
+const (
+	TournamentStatusInPreparation TournamentStatus = "inPreparation"
+	TournamentStatusInProgress    TournamentStatus = "inProgress"
+	TournamentStatusEnded         TournamentStatus = "ended"
+	TournamentStatusUnknown       TournamentStatus = "unknown"
+)
+
+func (s TournamentStatus) String() string {
+	return string(s)
+}
+
+func (s TournamentStatus) Valid() bool {
+	switch s {
+	case TournamentStatusInPreparation, TournamentStatusInProgress, TournamentStatusEnded, TournamentStatusUnknown:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,38 @@
+package clash_test
+
+import (
+	"encoding/json"
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRole_Outranks(t *testing.T) {
+	assert.True(t, clash.RoleLeader.Outranks(clash.RoleCoLeader))
+	assert.True(t, clash.RoleCoLeader.Outranks(clash.RoleElder))
+	assert.True(t, clash.RoleElder.Outranks(clash.RoleMember))
+	assert.False(t, clash.RoleMember.Outranks(clash.RoleMember))
+	assert.False(t, clash.Role("admiral").Outranks(clash.RoleMember))
+
+	assert.True(t, clash.RoleNotMember.Valid())
+	assert.False(t, clash.Role("admiral").Valid())
+}
+
+func TestEnumDecoding(t *testing.T) {
+	var member clash.ClanMember
+	assert.Nil(t, json.Unmarshal([]byte(`{"role":"coLeader"}`), &member))
+	assert.Equal(t, clash.RoleCoLeader, member.Role)
+
+	var battle clash.Battle
+	assert.Nil(t, json.Unmarshal([]byte(`{"type":"riverRacePvP","deckSelection":"collection"}`), &battle))
+	assert.Equal(t, clash.BattleTypeRiverRacePvP, battle.Type)
+	assert.True(t, battle.Type.IsClanWar())
+	assert.True(t, battle.DeckSelection.Valid())
+
+	var race clash.CurrentRiverRace
+	assert.Nil(t, json.Unmarshal([]byte(`{"state":"full","periodType":"colosseum"}`), &race))
+	assert.Equal(t, clash.RiverRaceStateFull, race.State)
+	assert.Equal(t, clash.RiverRacePeriodColosseum, race.PeriodType)
+	assert.True(t, race.State.Valid())
+	assert.False(t, clash.RiverRacePeriodType("brunch").Valid())
+}
@@ -0,0 +1,129 @@
+package clash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for common API failures. An *APIError matches these with errors.Is:
+//
+//	if errors.Is(err, clash.ErrNotFound) { ... }
+var (
+	// The request was malformed (400, reason "badRequest").
+	ErrBadRequest = errors.New("clash: bad request")
+	// The token was refused (403, reasons starting with "accessDenied").
+	ErrAccessDenied = errors.New("clash: access denied")
+	// The token isn't valid for this IP address (reason "accessDenied.invalidIp"). Also matches ErrAccessDenied.
+	ErrInvalidIP = errors.New("clash: access denied for this IP address")
+	// The requested resource doesn't exist (404, reason "notFound").
+	ErrNotFound = errors.New("clash: not found")
+	// Too many requests for the token's rate limit (429, reason "requestThrottled").
+	ErrThrottled = errors.New("clash: request throttled")
+	// The API failed to handle the request (500, reason "unknownException").
+	ErrServer = errors.New("clash: server error")
+	// The API is down for maintenance (503, reason "inMaintenance").
+	ErrMaintenance = errors.New("clash: in maintenance")
+)
+
+// The error response sent by the API if 4xx/5xx status code.
+type ErrorBody struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// How much of an error response body is kept in APIError.RawBody.
+const maxErrorBody = 4096
+
+// APIError implements the error interface. Use errors.As to retrieve it from an error returned by a service method.
+//
+// Every response with a status code >= 400 produces an APIError, even if its body isn't the API's JSON error
+// format (e.g. an HTML page from a load balancer, or an empty 502). Body is then empty, but never nil.
+type APIError struct {
+	Response *http.Response
+	Body     *ErrorBody
+	// Status code and headers of the response.
+	StatusCode int
+	Header     http.Header
+	// The start of the response body, as received.
+	RawBody []byte
+	// The request which failed.
+	Method string
+	URL    string
+}
+
+// build an APIError from a failed response and (the start of) its body.
+func newAPIError(resp *http.Response, raw []byte) *APIError {
+	body := &ErrorBody{}
+	json.Unmarshal(raw, body)
+
+	e := &APIError{
+		Response:   resp,
+		Body:       body,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RawBody:    raw,
+	}
+
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.URL = resp.Request.URL.String()
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.Body.Reason == "" && e.Body.Message == "" {
+		return fmt.Sprintf("[%d] %s", e.status(), http.StatusText(e.status()))
+	}
+
+	return fmt.Sprintf("[%d] %s: %s", e.status(), e.Body.Reason, e.Body.Message)
+}
+
+// the status code, whether or not the error was built by the client.
+func (e *APIError) status() int {
+	if e.StatusCode == 0 && e.Response != nil {
+		return e.Response.StatusCode
+	}
+
+	return e.StatusCode
+}
+
+// Match the sentinel errors by reason, falling back to the status code.
+func (e *APIError) Is(target error) bool {
+	reason := e.Body.Reason
+	status := e.status()
+
+	switch target {
+	case ErrBadRequest:
+		return reason == "badRequest" || status == http.StatusBadRequest
+	case ErrAccessDenied:
+		return strings.HasPrefix(reason, "accessDenied") || status == http.StatusForbidden
+	case ErrInvalidIP:
+		return reason == "accessDenied.invalidIp"
+	case ErrNotFound:
+		return reason == "notFound" || status == http.StatusNotFound
+	case ErrThrottled:
+		return reason == "requestThrottled" || status == http.StatusTooManyRequests
+	case ErrServer:
+		return reason == "unknownException" || status == http.StatusInternalServerError
+	case ErrMaintenance:
+		return reason == "inMaintenance" || status == http.StatusServiceUnavailable
+	}
+
+	return false
+}
+
+// Whether the error is likely to go away by itself, i.e. the request was throttled,
+// the API is in maintenance or the server failed. Such requests are worth retrying later.
+func IsTemporary(err error) bool {
+	if errors.Is(err, ErrThrottled) || errors.Is(err, ErrMaintenance) {
+		return true
+	}
+
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.status() >= 500
+}
@@ -0,0 +1,38 @@
+package clash_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func apiError(status int, reason string) error {
+	return &clash.APIError{
+		Response: &http.Response{StatusCode: status},
+		Body:     &clash.ErrorBody{Reason: reason},
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	invalidIP := fmt.Errorf("fetching player: %w", apiError(403, "accessDenied.invalidIp"))
+
+	assert.True(t, errors.Is(invalidIP, clash.ErrInvalidIP))
+	assert.True(t, errors.Is(invalidIP, clash.ErrAccessDenied))
+	assert.False(t, errors.Is(invalidIP, clash.ErrNotFound))
+	assert.True(t, errors.Is(apiError(404, "notFound"), clash.ErrNotFound))
+
+	var apiErr *clash.APIError
+	assert.True(t, errors.As(invalidIP, &apiErr))
+	assert.Equal(t, "accessDenied.invalidIp", apiErr.Body.Reason)
+}
+
+func TestIsTemporary(t *testing.T) {
+	assert.True(t, clash.IsTemporary(apiError(429, "requestThrottled")))
+	assert.True(t, clash.IsTemporary(apiError(503, "inMaintenance")))
+	assert.True(t, clash.IsTemporary(apiError(502, "")))
+	assert.False(t, clash.IsTemporary(apiError(404, "notFound")))
+	assert.False(t, clash.IsTemporary(errors.New("something else")))
+}
@@ -0,0 +1,157 @@
+package clash
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// How a KeyPool chooses the key for each request.
+type KeySelection int
+
+const (
+	// Cycle through the keys in order.
+	RoundRobin KeySelection = iota
+	// Use whichever key has been idle the longest.
+	LeastRecentlyUsed
+)
+
+// Usage statistics for one key in a KeyPool.
+type KeyUsage struct {
+	Token        string
+	Requests     int
+	Rejections   int
+	LastUsed     time.Time
+	BenchedUntil time.Time
+}
+
+// Whether the key is currently benched and won't be selected.
+func (k KeyUsage) Benched() bool {
+	return time.Now().Before(k.BenchedUntil)
+}
+
+// A set of API keys shared by a client. Keys which are rejected by the API (accessDenied, or throttled)
+// are benched for BenchDuration, and requests are rotated onto the remaining keys.
+type KeyPool struct {
+	Selection     KeySelection
+	BenchDuration time.Duration
+
+	mu   sync.Mutex
+	keys []*KeyUsage
+	next int
+}
+
+// Create a pool from one or more tokens, selected round-robin.
+func NewKeyPool(tokens ...string) *KeyPool {
+	pool := &KeyPool{BenchDuration: time.Minute}
+
+	for _, token := range tokens {
+		pool.keys = append(pool.keys, &KeyUsage{Token: token})
+	}
+
+	return pool
+}
+
+// Number of keys in the pool.
+func (p *KeyPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.keys)
+}
+
+// Snapshot of per-key usage counts.
+func (p *KeyPool) Usage() []KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := make([]KeyUsage, len(p.keys))
+
+	for i, key := range p.keys {
+		usage[i] = *key
+	}
+
+	return usage
+}
+
+// Take a key out of rotation for the pool's bench duration.
+func (p *KeyPool) Bench(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range p.keys {
+		if key.Token == token {
+			key.Rejections++
+			key.BenchedUntil = time.Now().Add(p.BenchDuration)
+		}
+	}
+}
+
+// choose the key for the next request. If every key is benched, the one due back soonest is used.
+func (p *KeyPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return ""
+	}
+
+	now := time.Now()
+	var chosen *KeyUsage
+
+	switch p.Selection {
+	case LeastRecentlyUsed:
+		for _, key := range p.keys {
+			if now.Before(key.BenchedUntil) {
+				continue
+			}
+
+			if chosen == nil || key.LastUsed.Before(chosen.LastUsed) {
+				chosen = key
+			}
+		}
+	default:
+		for i := range p.keys {
+			key := p.keys[(p.next+i)%len(p.keys)]
+
+			if !now.Before(key.BenchedUntil) {
+				chosen = key
+				p.next = (p.next + i + 1) % len(p.keys)
+				break
+			}
+		}
+	}
+
+	if chosen == nil {
+		for _, key := range p.keys {
+			if chosen == nil || key.BenchedUntil.Before(chosen.BenchedUntil) {
+				chosen = key
+			}
+		}
+	}
+
+	chosen.Requests++
+	chosen.LastUsed = now
+	return chosen.Token
+}
+
+// whether any key is currently available.
+func (p *KeyPool) available() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	for _, key := range p.keys {
+		if !now.Before(key.BenchedUntil) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// whether a response means the key itself was refused, rather than the request.
+func keyRejected(statusCode int, reason string) bool {
+	return statusCode == 429 || strings.HasPrefix(reason, "accessDenied")
+}
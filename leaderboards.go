@@ -0,0 +1,90 @@
+package clash
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+type Leaderboard struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type LeaderboardList struct {
+	Items []Leaderboard `json:"items"`
+}
+
+type LeaderboardPlayer struct {
+	Tag   string     `json:"tag"`
+	Name  string     `json:"name"`
+	Rank  int        `json:"rank"`
+	Score int        `json:"score"`
+	Clan  PlayerClan `json:"clan"`
+}
+
+type LeaderboardPlayerPager = Page[LeaderboardPlayer]
+
+type LeaderboardsService struct {
+	c *Client
+}
+
+type LeaderboardService struct {
+	c  *Client
+	id int
+}
+
+func (c *Client) Leaderboards() *LeaderboardsService {
+	return &LeaderboardsService{c}
+}
+
+func (c *Client) Leaderboard(id int) *LeaderboardService {
+	return &LeaderboardService{c, id}
+}
+
+// List leaderboards for different trophy roads.
+func (i *LeaderboardsService) All() (LeaderboardList, error) {
+	return i.AllContext(context.Background())
+}
+
+// Like All, but bound to ctx.
+func (i *LeaderboardsService) AllContext(ctx context.Context) (LeaderboardList, error) {
+	req, err := i.c.NewRequestWithContext(ctx, "GET", "/v1/leaderboards", nil)
+	var leaderboards LeaderboardList
+
+	if err == nil {
+		_, err = i.c.Do(req, &leaderboards)
+	}
+
+	return leaderboards, err
+}
+
+// Get the players on a leaderboard.
+func (i *LeaderboardService) Players(query *PagedQuery) (LeaderboardPlayerPager, error) {
+	return i.PlayersContext(context.Background(), query)
+}
+
+// Like Players, but bound to ctx.
+func (i *LeaderboardService) PlayersContext(ctx context.Context, query *PagedQuery) (LeaderboardPlayerPager, error) {
+	req, err := i.c.newQueryRequest(ctx, fmt.Sprintf("/v1/leaderboard/%d", i.id), query)
+	var players LeaderboardPlayerPager
+
+	if err == nil {
+		_, err = i.c.Do(req, &players)
+	}
+
+	players.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (LeaderboardPlayerPager, error) {
+		return i.PlayersContext(ctx, &page)
+	})
+
+	return players, err
+}
+
+// Iterate over every player on the leaderboard, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems players unless it is zero.
+func (i *LeaderboardService) PlayersIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[LeaderboardPlayer, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]LeaderboardPlayer, Paging, error) {
+		players, err := i.PlayersContext(ctx, page)
+		return players.Items, players.Paging, err
+	})
+}
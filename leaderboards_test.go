@@ -0,0 +1,63 @@
+package clash_test
+
+import (
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLeaderboardsService_All(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"items":[{"id":170000005,"name":"Path of Legend"}]}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	leaderboards, err := client.Leaderboards().All()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/leaderboards", path)
+	assert.Equal(t, 170000005, leaderboards.Items[0].ID)
+	assert.Equal(t, "Path of Legend", leaderboards.Items[0].Name)
+}
+
+func TestLeaderboardService_Players(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+
+		if r.URL.Query().Get("after") != "" {
+			w.Write([]byte(`{"items":[{"tag":"#3","rank":3,"score":2900}],"paging":{"cursors":{"before":"b"}}}`))
+			return
+		}
+
+		w.Write([]byte(`{"items":[{"tag":"#1","rank":1,"score":3100},{"tag":"#2","rank":2,"score":3000}],"paging":{"cursors":{"after":"a"}}}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	first, err := client.Leaderboard(170000005).Players(&clash.PagedQuery{Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 3100, first.Items[0].Score)
+
+	second, err := first.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "#3", second.Items[0].Tag)
+
+	_, err = second.Next()
+	assert.Equal(t, clash.ErrNoMorePages, err)
+
+	// nothing comes before the first page
+	_, err = first.Prev()
+	assert.Equal(t, clash.ErrNoMorePages, err)
+
+	assert.Equal(t, []string{
+		"/v1/leaderboard/170000005?limit=2",
+		"/v1/leaderboard/170000005?after=a&limit=2",
+	}, requests)
+}
@@ -1,11 +1,12 @@
 package clash
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"iter"
+)
 
-type LocationPager struct {
-	Items  []Location `json:"items"`
-	Paging Paging     `json:"paging"`
-}
+type LocationPager = Page[Location]
 
 type Location struct {
 	ID          int    `json:"id"`
@@ -14,15 +15,9 @@ type Location struct {
 	CountryCode string `json:"countryCode,omitempty"`
 }
 
-type LocationClanRankingPager struct {
-	Items  []ClanRanking `json:"items"`
-	Paging Paging        `json:"paging"`
-}
+type LocationClanRankingPager = Page[ClanRanking]
 
-type LocationPlayerRankingPager struct {
-	Items  []PlayerRanking `json:"items"`
-	Paging Paging          `json:"paging"`
-}
+type LocationPlayerRankingPager = Page[PlayerRanking]
 
 type ClanRanking struct {
 	Tag          string   `json:"tag"`
@@ -46,6 +41,17 @@ type PlayerRanking struct {
 	Arena        Arena      `json:"arena"`
 }
 
+type TournamentRanking struct {
+	Tag          string     `json:"tag"`
+	Name         string     `json:"name"`
+	Score        int        `json:"score"`
+	Rank         int        `json:"rank"`
+	PreviousRank int        `json:"previousRank"`
+	Clan         PlayerClan `json:"clan"`
+}
+
+type LocationTournamentRankingPager = Page[TournamentRanking]
+
 type LocationsService struct {
 	c *Client
 }
@@ -64,9 +70,14 @@ func (c *Client) Location(id string) *LocationService {
 	return &LocationService{c, id}
 }
 
-// List all available locations
-func (i *LocationsService) All() (LocationPager, error) {
-	req, err := i.c.NewRequest("GET", "/v1/locations", nil)
+// List all available locations. query may be nil to fetch the first page with the default size.
+func (i *LocationsService) All(query *PagedQuery) (LocationPager, error) {
+	return i.AllContext(context.Background(), query)
+}
+
+// Like All, but bound to ctx.
+func (i *LocationsService) AllContext(ctx context.Context, query *PagedQuery) (LocationPager, error) {
+	req, err := i.c.newQueryRequest(ctx, "/v1/locations", query)
 
 	var locations LocationPager
 
@@ -74,12 +85,30 @@ func (i *LocationsService) All() (LocationPager, error) {
 		_, err = i.c.Do(req, &locations)
 	}
 
+	locations.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (LocationPager, error) {
+		return i.AllContext(ctx, &page)
+	})
+
 	return locations, err
 }
 
+// Iterate over every location, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems locations unless it is zero.
+func (i *LocationsService) AllIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[Location, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]Location, Paging, error) {
+		locations, err := i.AllContext(ctx, page)
+		return locations.Items, locations.Paging, err
+	})
+}
+
 // Get information about specific location
 func (i *LocationService) Get() (Location, error) {
-	req, err := i.c.NewRequest("GET", fmt.Sprintf("/v1/locations/%s", i.id), nil)
+	return i.GetContext(context.Background())
+}
+
+// Like Get, but bound to ctx.
+func (i *LocationService) GetContext(ctx context.Context) (Location, error) {
+	req, err := i.c.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/v1/locations/%s", i.id), nil)
 
 	var location Location
 
@@ -92,23 +121,12 @@ func (i *LocationService) Get() (Location, error) {
 
 // Get clan rankings for a specific location
 func (i *LocationService) ClanRankings(query *PagedQuery) (LocationClanRankingPager, error) {
-	req, err := i.c.NewRequest("GET", fmt.Sprintf("/v1/locations/%s/rankings/clans", i.id), nil)
-
-	q := req.URL.Query()
-
-	if query.Limit > 0 {
-		q.Add("limit", fmt.Sprintf("%d", query.Limit))
-	}
-
-	if query.After > 0 {
-		q.Add("after", fmt.Sprintf("%d", query.After))
-	}
-
-	if query.Before > 0 {
-		q.Add("before", fmt.Sprintf("%d", query.Before))
-	}
+	return i.ClanRankingsContext(context.Background(), query)
+}
 
-	req.URL.RawQuery = q.Encode()
+// Like ClanRankings, but bound to ctx.
+func (i *LocationService) ClanRankingsContext(ctx context.Context, query *PagedQuery) (LocationClanRankingPager, error) {
+	req, err := i.c.newQueryRequest(ctx, fmt.Sprintf("/v1/locations/%s/rankings/clans", i.id), query)
 
 	var rankings LocationClanRankingPager
 
@@ -116,28 +134,21 @@ func (i *LocationService) ClanRankings(query *PagedQuery) (LocationClanRankingPa
 		_, err = i.c.Do(req, &rankings)
 	}
 
+	rankings.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (LocationClanRankingPager, error) {
+		return i.ClanRankingsContext(ctx, &page)
+	})
+
 	return rankings, err
 }
 
 // Get player rankings for a specific location
 func (i *LocationService) PlayerRankings(query *PagedQuery) (LocationPlayerRankingPager, error) {
-	req, err := i.c.NewRequest("GET", fmt.Sprintf("/v1/locations/%s/rankings/players", i.id), nil)
-
-	q := req.URL.Query()
-
-	if query.Limit > 0 {
-		q.Add("limit", fmt.Sprintf("%d", query.Limit))
-	}
-
-	if query.After > 0 {
-		q.Add("after", fmt.Sprintf("%d", query.After))
-	}
-
-	if query.Before > 0 {
-		q.Add("before", fmt.Sprintf("%d", query.Before))
-	}
+	return i.PlayerRankingsContext(context.Background(), query)
+}
 
-	req.URL.RawQuery = q.Encode()
+// Like PlayerRankings, but bound to ctx.
+func (i *LocationService) PlayerRankingsContext(ctx context.Context, query *PagedQuery) (LocationPlayerRankingPager, error) {
+	req, err := i.c.newQueryRequest(ctx, fmt.Sprintf("/v1/locations/%s/rankings/players", i.id), query)
 
 	var rankings LocationPlayerRankingPager
 
@@ -145,34 +156,89 @@ func (i *LocationService) PlayerRankings(query *PagedQuery) (LocationPlayerRanki
 		_, err = i.c.Do(req, &rankings)
 	}
 
+	rankings.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (LocationPlayerRankingPager, error) {
+		return i.PlayerRankingsContext(ctx, &page)
+	})
+
 	return rankings, err
 }
 
 // Get clan war rankings for a specific location
 func (i *LocationService) ClanWarRankings(query *PagedQuery) (LocationClanRankingPager, error) {
-	req, err := i.c.NewRequest("GET", fmt.Sprintf("/v1/locations/%s/rankings/clanwars", i.id), nil)
+	return i.ClanWarRankingsContext(context.Background(), query)
+}
 
-	q := req.URL.Query()
+// Like ClanWarRankings, but bound to ctx.
+func (i *LocationService) ClanWarRankingsContext(ctx context.Context, query *PagedQuery) (LocationClanRankingPager, error) {
+	req, err := i.c.newQueryRequest(ctx, fmt.Sprintf("/v1/locations/%s/rankings/clanwars", i.id), query)
 
-	if query.Limit > 0 {
-		q.Add("limit", fmt.Sprintf("%d", query.Limit))
-	}
+	var rankings LocationClanRankingPager
 
-	if query.After > 0 {
-		q.Add("after", fmt.Sprintf("%d", query.After))
+	if err == nil {
+		_, err = i.c.Do(req, &rankings)
 	}
 
-	if query.Before > 0 {
-		q.Add("before", fmt.Sprintf("%d", query.Before))
-	}
+	rankings.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (LocationClanRankingPager, error) {
+		return i.ClanWarRankingsContext(ctx, &page)
+	})
+
+	return rankings, err
+}
 
-	req.URL.RawQuery = q.Encode()
+// Iterate over every clan ranking for the location, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems rankings unless it is zero.
+func (i *LocationService) ClanRankingsIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[ClanRanking, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]ClanRanking, Paging, error) {
+		rankings, err := i.ClanRankingsContext(ctx, page)
+		return rankings.Items, rankings.Paging, err
+	})
+}
 
-	var rankings LocationClanRankingPager
+// Iterate over every player ranking for the location, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems rankings unless it is zero.
+func (i *LocationService) PlayerRankingsIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[PlayerRanking, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]PlayerRanking, Paging, error) {
+		rankings, err := i.PlayerRankingsContext(ctx, page)
+		return rankings.Items, rankings.Paging, err
+	})
+}
+
+// Iterate over every clan war ranking for the location, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems rankings unless it is zero.
+func (i *LocationService) ClanWarRankingsIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[ClanRanking, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]ClanRanking, Paging, error) {
+		rankings, err := i.ClanWarRankingsContext(ctx, page)
+		return rankings.Items, rankings.Paging, err
+	})
+}
+
+// Get global tournament rankings. Only available for the "global" location.
+func (i *LocationService) TournamentRankings(tag string, query *PagedQuery) (LocationTournamentRankingPager, error) {
+	return i.TournamentRankingsContext(context.Background(), tag, query)
+}
+
+// Like TournamentRankings, but bound to ctx.
+func (i *LocationService) TournamentRankingsContext(ctx context.Context, tag string, query *PagedQuery) (LocationTournamentRankingPager, error) {
+	req, err := i.c.newQueryRequest(ctx, fmt.Sprintf("/v1/locations/%s/rankings/tournaments/%s", i.id, NormaliseTag(tag)), query)
+
+	var rankings LocationTournamentRankingPager
 
 	if err == nil {
 		_, err = i.c.Do(req, &rankings)
 	}
 
+	rankings.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (LocationTournamentRankingPager, error) {
+		return i.TournamentRankingsContext(ctx, tag, &page)
+	})
+
 	return rankings, err
 }
+
+// Iterate over every player ranked in a global tournament, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems rankings unless it is zero.
+func (i *LocationService) TournamentRankingsIter(ctx context.Context, tag string, query *PagedQuery, maxItems int) iter.Seq2[TournamentRanking, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]TournamentRanking, Paging, error) {
+		rankings, err := i.TournamentRankingsContext(ctx, tag, page)
+		return rankings.Items, rankings.Paging, err
+	})
+}
@@ -0,0 +1,84 @@
+package clash
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Details of the response behind a service method's result.
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+	// When the response was generated. For cached responses this is when it was originally fetched.
+	FetchedAt time.Time
+	// Total time spent in the call, including rate limiting and retries.
+	Duration time.Duration
+	// Served from the cache without a request to the API.
+	FromCache bool
+	// Served from the cache after the API confirmed it was current with a 304 Not Modified.
+	Revalidated bool
+}
+
+type metaKey struct{}
+
+// the ResponseMeta carried by a context, locked so calls sharing the context don't race on it.
+type metaRecorder struct {
+	mu   sync.Mutex
+	meta *ResponseMeta
+}
+
+// Return a context which records the metadata of the response to a call made with it into meta:
+//
+//	var meta clash.ResponseMeta
+//	clan, err := client.Clan(tag).GetContext(clash.WithResponseMeta(ctx, &meta))
+//	fmt.Println(meta.Header.Get("Cache-Control"), meta.Duration)
+//
+// If a context is used for several calls, meta describes the last one to finish. A call which gets no
+// response, e.g. because the connection failed, leaves StatusCode zero and Header nil. Calls may share the
+// context concurrently, but meta must only be read once they have all returned, and then describes an
+// arbitrary one of them; give each goroutine its own context to see the metadata of every call.
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, metaKey{}, &metaRecorder{meta: meta})
+}
+
+// clear the metadata of a previous call. Safe to call on a nil recorder.
+func (r *metaRecorder) reset() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.meta = ResponseMeta{}
+}
+
+// record the outcome of a call which started at start. resp is nil if the call got no response.
+func (r *metaRecorder) record(resp *http.Response, start time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.meta = ResponseMeta{Duration: time.Since(start)}
+
+	if resp != nil {
+		r.meta.fill(resp, start)
+	}
+}
+
+func (m *ResponseMeta) fill(resp *http.Response, start time.Time) {
+	m.StatusCode = resp.StatusCode
+	m.Header = resp.Header
+	m.FromCache = FromCache(resp)
+	m.Revalidated = Revalidated(resp)
+	m.FetchedAt = start
+
+	if m.FromCache {
+		if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			m.FetchedAt = date
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package clash
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Anything which can send an HTTP request; *http.Client satisfies it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Wraps a Doer to observe or modify requests and responses. Middleware runs once per attempt,
+// after the rate limiter and key selection and before the response is decoded, so retries pass through it again.
+type Middleware func(next Doer) Doer
+
+// Add middleware to the client. The first middleware added is the outermost.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// Add middleware to the client at construction.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(middleware...)
+	}
+}
+
+// the http client wrapped in the client's middleware.
+func (c *Client) doer() Doer {
+	var doer Doer = c.httpClient
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		doer = c.middleware[i](doer)
+	}
+
+	return doer
+}
+
+// Middleware which logs each request at info level with its method, path, status and duration.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			attrs := []any{"method", req.Method, "path", req.URL.Path, "duration", time.Since(start)}
+
+			if err != nil {
+				logger.ErrorContext(req.Context(), "clash: request failed", append(attrs, "error", err)...)
+				return resp, err
+			}
+
+			logger.InfoContext(req.Context(), "clash: request", append(attrs, "status", resp.StatusCode)...)
+			return resp, err
+		})
+	}
+}
+
+// Middleware which reports how long each request took, e.g. to record metrics.
+// resp is nil if err is not.
+func TimingMiddleware(observe func(req *http.Request, resp *http.Response, duration time.Duration, err error)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			observe(req, resp, time.Since(start), err)
+			return resp, err
+		})
+	}
+}
+
+// Middleware which adds the given headers to every request, replacing any existing values.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			for name, values := range header {
+				req.Header[http.CanonicalHeaderKey(name)] = values
+			}
+
+			return next.Do(req)
+		})
+	}
+}
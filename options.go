@@ -0,0 +1,98 @@
+package clash
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Configures a Client when passed to NewClient.
+type Option func(*Client)
+
+// Send requests to a different API host, e.g. the RoyaleAPI proxy (https://proxy.royaleapi.dev) or a local stub.
+// An invalid URL is reported by the first request made with the client.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		u, err := url.Parse(baseURL)
+
+		if err != nil {
+			c.err = err
+			return
+		}
+
+		c.BaseURL = u
+	}
+}
+
+// Use the given http.Client for all requests. WithTransport and WithTimeout options, in any order,
+// apply to a copy, leaving the caller's client untouched.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// Send requests through the given transport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// Set the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// Give every request an overall time limit, including reading the response body.
+// Contexts passed to the ...Context methods can impose shorter deadlines.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = &timeout
+	}
+}
+
+// Log requests, retries and failures to the given logger. Requests are logged at debug level,
+// retries at info and unexpected responses at warn. A nil logger disables logging.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		if logger == nil {
+			logger = slog.New(slog.DiscardHandler)
+		}
+
+		c.logger = logger
+	}
+}
+
+// Retry throttled and failed requests according to the given policy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.Retry = policy
+	}
+}
+
+// Throttle outgoing requests with the given limiter.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) {
+		c.Limiter = limiter
+	}
+}
+
+// Cache GET responses in the given cache.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.Cache = cache
+	}
+}
+
+// Rotate between the keys in the given pool instead of using a single bearer token.
+func WithKeyPool(pool *KeyPool) Option {
+	return func(c *Client) {
+		c.Keys = pool
+	}
+}
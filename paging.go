@@ -0,0 +1,165 @@
+package clash
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// Returned by Next and Prev when there is no adjacent page to fetch.
+var ErrNoMorePages = errors.New("clash: no more pages")
+
+// Base struct for paged queries. After and Before take the opaque cursors from a previous page's Paging;
+// at most one of them should be set.
+type PagedQuery struct {
+	Limit  int    `url:"limit,omitempty"`
+	After  string `url:"after,omitempty"`
+	Before string `url:"before,omitempty"`
+}
+
+// Check the paging parameters are sensible.
+func (q *PagedQuery) Validate() error {
+	if q.Limit < 0 {
+		return &QueryError{"limit", "must not be negative"}
+	}
+
+	if q.After != "" && q.Before != "" {
+		return &QueryError{"after", "cannot be combined with before"}
+	}
+
+	return nil
+}
+
+// Paging for pager responses. 'before' and 'after' may be empty if there are no more results to return.
+type Paging struct {
+	Cursors struct {
+		Before string `json:"before"`
+		After  string `json:"after"`
+	} `json:"cursors"`
+}
+
+// Whether there is a page after this one.
+func (p Paging) HasNext() bool {
+	return p.Cursors.After != ""
+}
+
+// Whether there is a page before this one.
+func (p Paging) HasPrev() bool {
+	return p.Cursors.Before != ""
+}
+
+// One page of a paged endpoint. The pager types returned by service methods are aliases of this.
+type Page[T any] struct {
+	Items  []T    `json:"items"`
+	Paging Paging `json:"paging"`
+	cursor cursor[Page[T]]
+}
+
+// Fetch the next page of results with the same query. Returns ErrNoMorePages on the last page.
+func (p *Page[T]) Next() (Page[T], error) {
+	return p.NextContext(context.Background())
+}
+
+// Like Next, but bound to ctx.
+func (p *Page[T]) NextContext(ctx context.Context) (Page[T], error) {
+	return p.cursor.next(ctx, p.Paging)
+}
+
+// Fetch the previous page of results with the same query. Returns ErrNoMorePages on the first page.
+func (p *Page[T]) Prev() (Page[T], error) {
+	return p.PrevContext(context.Background())
+}
+
+// Like Prev, but bound to ctx.
+func (p *Page[T]) PrevContext(ctx context.Context) (Page[T], error) {
+	return p.cursor.prev(ctx, p.Paging)
+}
+
+// remembers how a page was fetched, so pagers can fetch their neighbours with the same query.
+type cursor[P any] struct {
+	query PagedQuery
+	fetch func(ctx context.Context, query PagedQuery) (P, error)
+}
+
+func newCursor[P any](query *PagedQuery, fetch func(ctx context.Context, query PagedQuery) (P, error)) cursor[P] {
+	c := cursor[P]{fetch: fetch}
+
+	if query != nil {
+		c.query = *query
+	}
+
+	return c
+}
+
+func (c cursor[P]) next(ctx context.Context, paging Paging) (P, error) {
+	if c.fetch == nil || !paging.HasNext() {
+		var empty P
+		return empty, ErrNoMorePages
+	}
+
+	query := c.query
+	query.After, query.Before = paging.Cursors.After, ""
+	return c.fetch(ctx, query)
+}
+
+func (c cursor[P]) prev(ctx context.Context, paging Paging) (P, error) {
+	if c.fetch == nil || !paging.HasPrev() {
+		var empty P
+		return empty, ErrNoMorePages
+	}
+
+	query := c.query
+	query.After, query.Before = "", paging.Cursors.Before
+	return c.fetch(ctx, query)
+}
+
+// walk the pages of an endpoint from the given query, yielding each item until the last page, maxItems (if non-zero)
+// or the first error. Iteration stops early if the consumer breaks out of the loop or ctx is cancelled.
+func paginate[T any](ctx context.Context, query *PagedQuery, maxItems int, fetch func(ctx context.Context, query *PagedQuery) ([]T, Paging, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		var page PagedQuery
+		count := 0
+
+		if query != nil {
+			page = *query
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			// don't fetch more than we're going to yield
+			if remaining := maxItems - count; maxItems > 0 && (page.Limit == 0 || page.Limit > remaining) {
+				page.Limit = remaining
+			}
+
+			items, paging, err := fetch(ctx, &page)
+
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+
+				count++
+
+				if maxItems > 0 && count >= maxItems {
+					return
+				}
+			}
+
+			if len(items) == 0 || !paging.HasNext() {
+				return
+			}
+
+			page.After, page.Before = paging.Cursors.After, ""
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package clash_test
+
+import (
+	"context"
+	"errors"
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// serves two pages of player rankings, linked by opaque cursors.
+func rankingServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("after") {
+		case "":
+			w.Write([]byte(`{"items":[{"tag":"#1","rank":1},{"tag":"#2","rank":2}],"paging":{"cursors":{"after":"eyJwb3MiOjJ9"}}}`))
+		case "eyJwb3MiOjJ9":
+			w.Write([]byte(`{"items":[{"tag":"#3","rank":3}],"paging":{"cursors":{"before":"eyJwb3MiOjN9"}}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestPager_Next(t *testing.T) {
+	srv := rankingServer()
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	first, err := client.Location("global").PlayerRankings(&clash.PagedQuery{Limit: 2})
+	assert.Nil(t, err)
+	assert.True(t, first.Paging.HasNext())
+	assert.False(t, first.Paging.HasPrev())
+
+	second, err := first.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "#3", second.Items[0].Tag)
+
+	_, err = second.Next()
+	assert.Equal(t, clash.ErrNoMorePages, err)
+
+	_, err = first.Prev()
+	assert.Equal(t, clash.ErrNoMorePages, err)
+}
+
+func TestPager_Iter(t *testing.T) {
+	srv := rankingServer()
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+	location := client.Location("global")
+
+	var tags []string
+	for player, err := range location.PlayerRankingsIter(context.Background(), nil, 0) {
+		assert.Nil(t, err)
+		tags = append(tags, player.Tag)
+	}
+	assert.Equal(t, []string{"#1", "#2", "#3"}, tags)
+
+	tags = nil
+	for player, err := range location.PlayerRankingsIter(context.Background(), &clash.PagedQuery{Limit: 2}, 1) {
+		assert.Nil(t, err)
+		tags = append(tags, player.Tag)
+	}
+	assert.Equal(t, []string{"#1"}, tags)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range location.PlayerRankingsIter(ctx, nil, 0) {
+		assert.Equal(t, context.Canceled, err)
+	}
+}
+
+func TestClanService_Members(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(`{"items":[{"tag":"#1"}],"paging":{"cursors":{"after":"abc"}}}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	members, err := client.Clan("#222").Members(&clash.PagedQuery{Limit: 1})
+	assert.Nil(t, err)
+	_, err = members.Next()
+	assert.Nil(t, err)
+	_, err = client.Clan("#222").Members(nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"limit=1", "after=abc&limit=1", ""}, queries)
+}
+
+func TestLocationService_TournamentRankings(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.Write([]byte(`{"items":[{"tag":"#1","score":12,"rank":1}],"paging":{"cursors":{}}}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	rankings, err := client.Location("global").TournamentRankings("2PP", &clash.PagedQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/locations/global/rankings/tournaments/%232PP", path)
+	assert.Equal(t, 12, rankings.Items[0].Score)
+
+	_, err = rankings.Next()
+	assert.Equal(t, clash.ErrNoMorePages, err)
+}
+
+// search iterators accept a nil query like their Search counterparts.
+func TestSearchIter_NilQuery(t *testing.T) {
+	srv := rankingServer()
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	// clan search needs a filter, so this fails validation rather than panicking
+	for _, err := range client.Clans().SearchIter(context.Background(), nil, 0) {
+		var queryErr *clash.QueryError
+		assert.True(t, errors.As(err, &queryErr))
+	}
+
+	var tags []string
+	for tournament, err := range client.Tournaments().SearchIter(context.Background(), nil, 0) {
+		assert.Nil(t, err)
+		tags = append(tags, tournament.Tag)
+	}
+	assert.Equal(t, []string{"#1", "#2", "#3"}, tags)
+}
@@ -1,36 +1,11 @@
 package clash
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"time"
 )
 
-type Card struct {
-	Name      string   `json:"name"`
-	Level     int      `json:"level"`
-	MaxLevel  int      `json:"maxLevel"`
-	Count     int      `json:"count"`
-	IconUrls  IconUrls `json:"iconUrls"`
-	StarLevel int      `json:"starLevel"`
-}
-
-// Return the internal client level for the card, as these are zero-indexed
-func (c *Card) ClientLevel() int {
-	return c.Level - 1
-}
-
-type FavouriteCard struct {
-	Name     string   `json:"name"`
-	ID       int      `json:"id"`
-	MaxLevel int      `json:"maxLevel"`
-	IconUrls IconUrls `json:"iconUrls"`
-}
-
-type IconUrls struct {
-	Medium string `json:"medium"`
-}
-
 type Achievement struct {
 	Name   string `json:"name"`
 	Stars  int    `json:"stars"`
@@ -85,7 +60,7 @@ type Player struct {
 	ChallengeMaxWins      int           `json:"challengeMaxWins"`
 	TournamentCardsWon    int           `json:"tournamentCardsWon"`
 	TournamentBattleCount int           `json:"tournamentBattleCount"`
-	Role                  string        `json:"role"`
+	Role                  Role          `json:"role"`
 	Donations             int           `json:"donations"`
 	DonationsReceived     int           `json:"donationsReceived"`
 	TotalDonations        int           `json:"totalDonations"`
@@ -100,6 +75,10 @@ type Player struct {
 	CurrentFavouriteCard  FavouriteCard `json:"currentFavouriteCard"`
 	LeagueStatistics      LeagueStats   `json:"leagueStatistics"`
 	StarPoints            int           `json:"starPoints"`
+
+	CurrentPathOfLegendSeasonResult PathOfLegendSeasonResult `json:"currentPathOfLegendSeasonResult"`
+	LastPathOfLegendSeasonResult    PathOfLegendSeasonResult `json:"lastPathOfLegendSeasonResult"`
+	BestPathOfLegendSeasonResult    PathOfLegendSeasonResult `json:"bestPathOfLegendSeasonResult"`
 }
 
 type VerificationResult struct {
@@ -127,7 +106,7 @@ type BattlePlayer struct {
 	KingTowerHitPoints int `json:"kingTowerHitPoints,omitempty"`
 	// ditto. princess tower hit points are an array of values.
 	PrincessTowersHitPoints []int `json:"princessTowersHitPoints,omitempty"`
-	Clan struct {
+	Clan                    struct {
 		Tag     string `json:"tag"`
 		Name    string `json:"name"`
 		BadgeId int    `json:"badgeId"`
@@ -136,11 +115,11 @@ type BattlePlayer struct {
 }
 
 type Battle struct {
-	Type                    string         `json:"type"`
-	RawBattleTime           string         `json:"battleTime"`
+	Type                    BattleType     `json:"type"`
+	BattleTime              Time           `json:"battleTime"`
 	Arena                   Arena          `json:"arena"`
 	GameMode                GameMode       `json:"gameMode"`
-	DeckSelection           string         `json:"deckSelection"`
+	DeckSelection           DeckSelection  `json:"deckSelection"`
 	Team                    []BattlePlayer `json:"team"`
 	Opponent                []BattlePlayer `json:"opponent"`
 	TournamentTag           string         `json:"tournamentTag"`
@@ -179,12 +158,6 @@ func (b *Battle) Outcome() BattleOutcome {
 	return BattleOutcome{IsDraw: true}
 }
 
-// Get the time of the battle.
-func (b *Battle) BattleTime() time.Time {
-	parsed, _ := time.Parse(TimeLayout, b.RawBattleTime)
-	return parsed
-}
-
 type Battles []Battle
 
 type UpcomingChest struct {
@@ -207,8 +180,13 @@ func (c *Client) Player(tag string) *PlayerService {
 
 // Get list of reward chests that the player will receive next in the game.
 func (i *PlayerService) UpcomingChests() (UpcomingChests, error) {
+	return i.UpcomingChestsContext(context.Background())
+}
+
+// Like UpcomingChests, but bound to ctx.
+func (i *PlayerService) UpcomingChestsContext(ctx context.Context) (UpcomingChests, error) {
 	url := fmt.Sprintf("/v1/players/%s/upcomingchests", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.NewRequestWithContext(ctx, "GET", url, nil)
 	var chests UpcomingChests
 
 	if err == nil {
@@ -220,8 +198,13 @@ func (i *PlayerService) UpcomingChests() (UpcomingChests, error) {
 
 // Get list of recent battle results for a player.
 func (i *PlayerService) BattleLog() (Battles, error) {
+	return i.BattleLogContext(context.Background())
+}
+
+// Like BattleLog, but bound to ctx.
+func (i *PlayerService) BattleLogContext(ctx context.Context) (Battles, error) {
 	url := fmt.Sprintf("/v1/players/%s/battlelog", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.NewRequestWithContext(ctx, "GET", url, nil)
 	var list Battles
 
 	if err == nil {
@@ -234,8 +217,13 @@ func (i *PlayerService) BattleLog() (Battles, error) {
 // Get information about a single player by player tag. Player tags
 // can be found either in game or by from clan member lists.
 func (i *PlayerService) Get() (Player, error) {
+	return i.GetContext(context.Background())
+}
+
+// Like Get, but bound to ctx.
+func (i *PlayerService) GetContext(ctx context.Context) (Player, error) {
 	url := fmt.Sprintf("/v1/players/%s", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.NewRequestWithContext(ctx, "GET", url, nil)
 	var player Player
 
 	if err == nil {
@@ -250,8 +238,13 @@ func (i *PlayerService) Get() (Player, error) {
 // This API call can be used by a player to prove that they own a particular game account as the token
 // can only be retrieved inside the game from settings view.
 func (i *PlayerService) VerifyToken(token string) (VerificationResult, error) {
+	return i.VerifyTokenContext(context.Background(), token)
+}
+
+// Like VerifyToken, but bound to ctx.
+func (i *PlayerService) VerifyTokenContext(ctx context.Context, token string) (VerificationResult, error) {
 	url := fmt.Sprintf("/v1/players/%s/verifytoken", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("POST", url, map[string]string{"token": token})
+	req, err := i.c.NewRequestWithContext(ctx, "POST", url, map[string]string{"token": token})
 	var result VerificationResult
 
 	if err == nil {
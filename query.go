@@ -0,0 +1,122 @@
+package clash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Returned when a query fails client-side validation, before any request is made.
+type QueryError struct {
+	// The offending parameter, or empty if the query as a whole is invalid.
+	Param  string
+	Reason string
+}
+
+func (e *QueryError) Error() string {
+	if e.Param == "" {
+		return fmt.Sprintf("clash: invalid query: %s", e.Reason)
+	}
+
+	return fmt.Sprintf("clash: invalid query parameter %s: %s", e.Param, e.Reason)
+}
+
+// Implemented by queries which can check their parameters before they are sent.
+type validator interface {
+	Validate() error
+}
+
+// make a GET request to path with the query's fields in the query string. query is a (possibly nil) pointer
+// to a struct whose fields are tagged with their parameter name, e.g. `url:"minMembers,omitempty"`.
+// Queries implementing Validate are validated first.
+func (c *Client) newQueryRequest(ctx context.Context, path string, query interface{}) (*http.Request, error) {
+	if v, ok := query.(validator); ok && !reflect.ValueOf(query).IsNil() {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	values, err := encodeQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequestWithContext(ctx, "GET", path, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.URL.RawQuery = values.Encode()
+	return req, nil
+}
+
+// encode a query struct into url.Values. Fields are named by their `url` tag, and skipped when the
+// tag is missing or "-", or when tagged omitempty and zero. Embedded structs are flattened.
+func encodeQuery(query interface{}) (url.Values, error) {
+	values := url.Values{}
+	v := reflect.ValueOf(query)
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return values, nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("clash: cannot encode %s as a query", v.Type())
+	}
+
+	return values, encodeStruct(values, v)
+}
+
+func encodeStruct(values url.Values, v reflect.Value) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		tag := field.Tag.Get("url")
+
+		if field.Anonymous && tag == "" && value.Kind() == reflect.Struct {
+			if err := encodeStruct(values, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if tag == "" || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if opts == "omitempty" && value.IsZero() {
+			continue
+		}
+
+		switch value.Kind() {
+		case reflect.String:
+			values.Add(name, value.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			values.Add(name, strconv.FormatInt(value.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			values.Add(name, strconv.FormatUint(value.Uint(), 10))
+		case reflect.Bool:
+			values.Add(name, strconv.FormatBool(value.Bool()))
+		case reflect.Float32, reflect.Float64:
+			values.Add(name, strconv.FormatFloat(value.Float(), 'f', -1, 64))
+		default:
+			return fmt.Errorf("clash: cannot encode query field %s of type %s", field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
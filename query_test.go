@@ -0,0 +1,46 @@
+package clash_test
+
+import (
+	"errors"
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClanQuery_Validate(t *testing.T) {
+	var queryErr *clash.QueryError
+	assert.True(t, errors.As((&clash.ClanQuery{}).Validate(), &queryErr))
+	assert.Equal(t, "", queryErr.Param)
+	assert.Equal(t, "clash: invalid query: at least one filter must be set", queryErr.Error())
+	assert.NotNil(t, (&clash.ClanQuery{Name: "ab"}).Validate())
+	assert.NotNil(t, (&clash.ClanQuery{MinMembers: 1}).Validate())
+	assert.NotNil(t, (&clash.ClanQuery{MaxMembers: 51}).Validate())
+	assert.NotNil(t, (&clash.ClanQuery{MinMembers: 30, MaxMembers: 20}).Validate())
+	assert.Nil(t, (&clash.ClanQuery{Name: "abc", MinMembers: 2, MaxMembers: 50}).Validate())
+}
+
+func TestQueryEncoding(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	_, err := client.Clans().Search(&clash.ClanQuery{Name: "royale", MinMembers: 40, PagedQuery: clash.PagedQuery{Limit: 5}})
+	assert.Nil(t, err)
+	_, err = client.Tournaments().Search(&clash.TournamentQuery{PagedQuery: clash.PagedQuery{After: "abc"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"limit=5&minMembers=40&name=royale", "after=abc"}, queries)
+
+	// invalid queries never reach the API
+	_, err = client.Clans().Search(&clash.ClanQuery{Name: "ro"})
+	var queryErr *clash.QueryError
+	assert.True(t, errors.As(err, &queryErr))
+	assert.Equal(t, "name", queryErr.Param)
+	assert.Equal(t, 2, len(queries))
+}
@@ -0,0 +1,78 @@
+package clash
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Anything which can throttle outgoing requests. Wait blocks until a request may be sent,
+// returning early with the context's error if it is cancelled first.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// A token bucket rate limiter, safe for concurrent use. Tokens refill continuously at the configured
+// rate up to the burst size; each request consumes one.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// Create a token bucket allowing rps requests per second on average, and up to burst requests at once.
+// The bucket starts full.
+func NewTokenBucket(rps float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay, ok := b.reserve()
+
+		if ok {
+			return nil
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// take a token if one is available, otherwise report how long until the next one is.
+func (b *TokenBucket) reserve() (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	b.last = now
+
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+
+	if b.rate <= 0 {
+		// never refills; poll rather than block forever without honouring the context
+		return time.Second, false
+	}
+
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second)), false
+}
@@ -0,0 +1,23 @@
+package clash_test
+
+import (
+	"context"
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket := clash.NewTokenBucket(1, 2)
+
+	// the burst is available immediately
+	assert.Nil(t, bucket.Wait(context.Background()))
+	assert.Nil(t, bucket.Wait(context.Background()))
+
+	// the next token is a second away, so a short deadline should expire first
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, bucket.Wait(ctx))
+}
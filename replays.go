@@ -1,14 +1,18 @@
 package clash
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type Replay struct {
-	BattleTime string `json:"battleTime"`
-	// Replay data is hideously unstructured, so let's save some time.
+	BattleTime Time `json:"battleTime"`
+	// Replay data is hideously unstructured and its schema undocumented, so it is left undecoded
+	// until it can be modelled from captured responses.
 	ReplayData map[string]interface{} `json:"replayData"`
-	ShareCount int `json:"shareCount"`
-	Tag string `json:"tag"`
-	ViewCount int `json:"viewCount"`
+	ShareCount int                    `json:"shareCount"`
+	Tag        string                 `json:"tag"`
+	ViewCount  int                    `json:"viewCount"`
 }
 
 type ReplayService struct {
@@ -22,8 +26,13 @@ func (c *Client) Replay(tag string) *ReplayService {
 
 // Get information about a single replay by a replay tag.
 func (i *ReplayService) Get() (Replay, error) {
+	return i.GetContext(context.Background())
+}
+
+// Like Get, but bound to ctx.
+func (i *ReplayService) GetContext(ctx context.Context) (Replay, error) {
 	url := fmt.Sprintf("/v1/replays/%s", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.NewRequestWithContext(ctx, "GET", url, nil)
 	var replay Replay
 
 	if err == nil {
@@ -31,4 +40,4 @@ func (i *ReplayService) Get() (Replay, error) {
 	}
 
 	return replay, err
-}
\ No newline at end of file
+}
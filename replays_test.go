@@ -0,0 +1,27 @@
+package clash_test
+
+import (
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReplayService_Get(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.Write([]byte(`{"tag":"#ABC","battleTime":"20180712T110230.000Z","viewCount":3,"replayData":{"anything":[1,2]}}`))
+	}))
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	replay, err := client.Replay("ABC").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "/v1/replays/%23ABC", path)
+	assert.Equal(t, int64(1531393350), replay.BattleTime.Unix())
+	assert.Equal(t, 3, replay.ViewCount)
+	assert.Equal(t, []interface{}{1.0, 2.0}, replay.ReplayData["anything"])
+}
@@ -0,0 +1,148 @@
+package clash
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Controls how Client.Do retries failed requests. A nil policy on the client means every request is attempted once.
+type RetryPolicy struct {
+	// Total number of attempts, including the first. Values below 2 disable retries.
+	MaxAttempts int
+	// Delay before the first retry; doubled on each subsequent attempt.
+	BaseDelay time.Duration
+	// Upper bound for the computed backoff. A Retry-After header sent by the server takes precedence.
+	MaxDelay time.Duration
+	// Fraction of each delay that is randomised, so parallel callers don't retry in lockstep.
+	// Must be between 0 and 1; values outside that range are clamped to it.
+	Jitter float64
+	// Response status codes which may be retried.
+	StatusCodes []int
+	// API error reasons (see ErrorBody) which may be retried regardless of status code.
+	Reasons []string
+	// By default only idempotent requests are retried. Set this to also retry e.g. VerifyToken's POST.
+	RetryNonIdempotent bool
+}
+
+// A sensible default: up to 4 attempts on throttling and maintenance responses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		Reasons: []string{"requestThrottled", "inMaintenance"},
+	}
+}
+
+// whether the request may be attempted again after the given (failed) response.
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, reason string, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+
+	// the body must be replayable if there is one
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	for _, code := range p.StatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+
+	for _, r := range p.Reasons {
+		if reason != "" && reason == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+// how long to wait before the next attempt. attempt is the number of the attempt that just failed, starting at 1.
+func (p *RetryPolicy) backoff(resp *http.Response, attempt int) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return d
+	}
+
+	// saturate rather than let the shift wrap around into a negative or tiny delay
+	delay, shift := p.BaseDelay, uint(attempt-1)
+	if shift >= 63 || delay > math.MaxInt64>>shift {
+		delay = math.MaxInt64
+	} else {
+		delay <<= shift
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	// a jitter above 1 would make the delay negative and retry immediately
+	if jitter := min(p.Jitter, 1); jitter > 0 {
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// parse the Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		d := time.Until(at)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+
+	return false
+}
+
+// wait for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
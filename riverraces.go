@@ -0,0 +1,144 @@
+package clash
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
+
+type RiverRaceParticipant struct {
+	Tag            string `json:"tag"`
+	Name           string `json:"name"`
+	Fame           int    `json:"fame"`
+	RepairPoints   int    `json:"repairPoints"`
+	BoatAttacks    int    `json:"boatAttacks"`
+	DecksUsed      int    `json:"decksUsed"`
+	DecksUsedToday int    `json:"decksUsedToday"`
+}
+
+type RiverRaceClan struct {
+	Tag          string `json:"tag"`
+	Name         string `json:"name"`
+	BadgeId      int    `json:"badgeId"`
+	Fame         int    `json:"fame"`
+	RepairPoints int    `json:"repairPoints"`
+	PeriodPoints int    `json:"periodPoints"`
+	ClanScore    int    `json:"clanScore"`
+	// Zero or, as the live API sends it, "19691231T235959.000Z" if the clan hasn't finished. Use Finished to check.
+	FinishTime   Time                   `json:"finishTime"`
+	Participants []RiverRaceParticipant `json:"participants"`
+}
+
+// Whether the clan has crossed the finish line. Unfinished clans have an absent or pre-epoch finish time.
+func (c *RiverRaceClan) Finished() bool {
+	return !c.FinishTime.Before(time.Unix(0, 0))
+}
+
+// One clan's progress over a single day (period) of the race.
+type PeriodLogEntry struct {
+	Clan struct {
+		Tag string `json:"tag"`
+	} `json:"clan"`
+	PointsEarned               int `json:"pointsEarned"`
+	ProgressStartOfDay         int `json:"progressStartOfDay"`
+	ProgressEndOfDay           int `json:"progressEndOfDay"`
+	EndOfDayRank               int `json:"endOfDayRank"`
+	ProgressEarned             int `json:"progressEarned"`
+	NumOfDefensesRemaining     int `json:"numOfDefensesRemaining"`
+	ProgressEarnedFromDefenses int `json:"progressEarnedFromDefenses"`
+}
+
+type PeriodLog struct {
+	PeriodIndex int              `json:"periodIndex"`
+	Items       []PeriodLogEntry `json:"items"`
+}
+
+type CurrentRiverRace struct {
+	State             RiverRaceState      `json:"state"`
+	Clan              RiverRaceClan       `json:"clan"`
+	Clans             []RiverRaceClan     `json:"clans"`
+	CollectionEndTime Time                `json:"collectionEndTime"`
+	WarEndTime        Time                `json:"warEndTime"`
+	SectionIndex      int                 `json:"sectionIndex"`
+	PeriodIndex       int                 `json:"periodIndex"`
+	PeriodType        RiverRacePeriodType `json:"periodType"`
+	PeriodLogs        []PeriodLog         `json:"periodLogs"`
+}
+
+type RiverRaceStanding struct {
+	Rank         int           `json:"rank"`
+	TrophyChange int           `json:"trophyChange"`
+	Clan         RiverRaceClan `json:"clan"`
+}
+
+type RiverRaceLogEntry struct {
+	SeasonId     int                 `json:"seasonId"`
+	SectionIndex int                 `json:"sectionIndex"`
+	CreatedDate  Time                `json:"createdDate"`
+	Standings    []RiverRaceStanding `json:"standings"`
+}
+
+// Find the standing of a clan in the race by tag.
+func (r *RiverRaceLogEntry) StandingByTag(tag string) (RiverRaceStanding, bool) {
+	tag = NormaliseTag(tag)
+
+	for _, standing := range r.Standings {
+		if standing.Clan.Tag == tag {
+			return standing, true
+		}
+	}
+
+	return RiverRaceStanding{}, false
+}
+
+type RiverRaceLogPager = Page[RiverRaceLogEntry]
+
+// Retrieve information about clan's current river race
+func (i *ClanService) CurrentRiverRace() (CurrentRiverRace, error) {
+	return i.CurrentRiverRaceContext(context.Background())
+}
+
+// Like CurrentRiverRace, but bound to ctx.
+func (i *ClanService) CurrentRiverRaceContext(ctx context.Context) (CurrentRiverRace, error) {
+	url := fmt.Sprintf("/v1/clans/%s/currentriverrace", NormaliseTag(i.tag))
+	req, err := i.c.NewRequestWithContext(ctx, "GET", url, nil)
+	var race CurrentRiverRace
+
+	if err == nil {
+		_, err = i.c.Do(req, &race)
+	}
+
+	return race, err
+}
+
+// Retrieve clan's river race log. query may be nil to fetch the first page with the default size.
+func (i *ClanService) RiverRaceLog(query *PagedQuery) (RiverRaceLogPager, error) {
+	return i.RiverRaceLogContext(context.Background(), query)
+}
+
+// Like RiverRaceLog, but bound to ctx.
+func (i *ClanService) RiverRaceLogContext(ctx context.Context, query *PagedQuery) (RiverRaceLogPager, error) {
+	url := fmt.Sprintf("/v1/clans/%s/riverracelog", NormaliseTag(i.tag))
+	req, err := i.c.newQueryRequest(ctx, url, query)
+	var log RiverRaceLogPager
+
+	if err == nil {
+		_, err = i.c.Do(req, &log)
+	}
+
+	log.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (RiverRaceLogPager, error) {
+		return i.RiverRaceLogContext(ctx, &page)
+	})
+
+	return log, err
+}
+
+// Iterate over every race in the clan's river race log, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems races unless it is zero.
+func (i *ClanService) RiverRaceLogIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[RiverRaceLogEntry, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]RiverRaceLogEntry, Paging, error) {
+		log, err := i.RiverRaceLogContext(ctx, page)
+		return log.Items, log.Paging, err
+	})
+}
@@ -0,0 +1,40 @@
+package clash_test
+
+import (
+	"encoding/json"
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const riverRaceLogEntry = `{
+	"seasonId": 92,
+	"sectionIndex": 3,
+	"createdDate": "20230612T094105.000Z",
+	"standings": [
+		{"rank": 1, "trophyChange": 20, "clan": {"tag": "#222", "fame": 10000, "finishTime": "20230611T101500.000Z",
+			"participants": [{"tag": "#111", "fame": 3600, "decksUsed": 16, "boatAttacks": 2}]}},
+		{"rank": 2, "trophyChange": 10, "clan": {"tag": "#333", "fame": 8600, "finishTime": "19691231T235959.000Z"}},
+		{"rank": 3, "trophyChange": -10, "clan": {"tag": "#444", "fame": 7200}}
+	]
+}`
+
+func TestRiverRaceLogEntry(t *testing.T) {
+	var entry clash.RiverRaceLogEntry
+	assert.Nil(t, json.Unmarshal([]byte(riverRaceLogEntry), &entry))
+
+	assert.Equal(t, int64(1686562865), entry.CreatedDate.Unix())
+
+	winner, ok := entry.StandingByTag("222")
+	assert.True(t, ok)
+	assert.True(t, winner.Clan.Finished())
+	assert.Equal(t, 16, winner.Clan.Participants[0].DecksUsed)
+
+	// the live API sends a timestamp just before the epoch for clans which haven't finished
+	runnerUp, _ := entry.StandingByTag("#333")
+	assert.False(t, runnerUp.Clan.Finished())
+
+	last, _ := entry.StandingByTag("#444")
+	assert.False(t, last.Clan.Finished())
+	assert.True(t, last.Clan.FinishTime.IsZero())
+}
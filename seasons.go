@@ -0,0 +1,160 @@
+package clash
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// A player's result in a Path of Legends season.
+type PathOfLegendSeasonResult struct {
+	LeagueNumber int `json:"leagueNumber"`
+	Trophies     int `json:"trophies"`
+	Rank         int `json:"rank"`
+}
+
+type PathOfLegendRanking struct {
+	Tag       string     `json:"tag"`
+	Name      string     `json:"name"`
+	ExpLevel  int        `json:"expLevel"`
+	EloRating int        `json:"eloRating"`
+	Rank      int        `json:"rank"`
+	Clan      PlayerClan `json:"clan"`
+}
+
+type LocationPathOfLegendRankingPager = Page[PathOfLegendRanking]
+
+// A trophy road league season, identified by year and month, e.g. "2023-06".
+type LeagueSeason struct {
+	ID string `json:"id"`
+}
+
+type LeagueSeasonList struct {
+	Items []LeagueSeason `json:"items"`
+}
+
+type LeagueSeasonV2 struct {
+	Code     string `json:"code"`
+	UniqueID int    `json:"uniqueId"`
+	EndTime  Time   `json:"endTime"`
+}
+
+type LeagueSeasonV2List struct {
+	Items []LeagueSeasonV2 `json:"items"`
+}
+
+// Get Path of Legends player rankings for a specific location
+func (i *LocationService) PathOfLegendRankings(query *PagedQuery) (LocationPathOfLegendRankingPager, error) {
+	return i.PathOfLegendRankingsContext(context.Background(), query)
+}
+
+// Like PathOfLegendRankings, but bound to ctx.
+func (i *LocationService) PathOfLegendRankingsContext(ctx context.Context, query *PagedQuery) (LocationPathOfLegendRankingPager, error) {
+	req, err := i.c.newQueryRequest(ctx, fmt.Sprintf("/v1/locations/%s/pathoflegend/players", i.id), query)
+
+	var rankings LocationPathOfLegendRankingPager
+
+	if err == nil {
+		_, err = i.c.Do(req, &rankings)
+	}
+
+	rankings.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (LocationPathOfLegendRankingPager, error) {
+		return i.PathOfLegendRankingsContext(ctx, &page)
+	})
+
+	return rankings, err
+}
+
+// Iterate over every Path of Legends ranking for the location, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems rankings unless it is zero.
+func (i *LocationService) PathOfLegendRankingsIter(ctx context.Context, query *PagedQuery, maxItems int) iter.Seq2[PathOfLegendRanking, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]PathOfLegendRanking, Paging, error) {
+		rankings, err := i.PathOfLegendRankingsContext(ctx, page)
+		return rankings.Items, rankings.Paging, err
+	})
+}
+
+// List league seasons. Only available for the "global" location.
+func (i *LocationService) Seasons() (LeagueSeasonList, error) {
+	return i.SeasonsContext(context.Background())
+}
+
+// Like Seasons, but bound to ctx.
+func (i *LocationService) SeasonsContext(ctx context.Context) (LeagueSeasonList, error) {
+	req, err := i.c.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/v1/locations/%s/seasons", i.id), nil)
+
+	var seasons LeagueSeasonList
+
+	if err == nil {
+		_, err = i.c.Do(req, &seasons)
+	}
+
+	return seasons, err
+}
+
+// List league seasons with more details. Only available for the "global" location.
+func (i *LocationService) SeasonsV2() (LeagueSeasonV2List, error) {
+	return i.SeasonsV2Context(context.Background())
+}
+
+// Like SeasonsV2, but bound to ctx.
+func (i *LocationService) SeasonsV2Context(ctx context.Context) (LeagueSeasonV2List, error) {
+	req, err := i.c.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/v1/locations/%s/seasonsV2", i.id), nil)
+
+	var seasons LeagueSeasonV2List
+
+	if err == nil {
+		_, err = i.c.Do(req, &seasons)
+	}
+
+	return seasons, err
+}
+
+// Get league season information. Only available for the "global" location.
+func (i *LocationService) Season(id string) (LeagueSeason, error) {
+	return i.SeasonContext(context.Background(), id)
+}
+
+// Like Season, but bound to ctx.
+func (i *LocationService) SeasonContext(ctx context.Context, id string) (LeagueSeason, error) {
+	req, err := i.c.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/v1/locations/%s/seasons/%s", i.id, id), nil)
+
+	var season LeagueSeason
+
+	if err == nil {
+		_, err = i.c.Do(req, &season)
+	}
+
+	return season, err
+}
+
+// Get the top player rankings of a league season. Only available for the "global" location.
+func (i *LocationService) SeasonPlayerRankings(id string, query *PagedQuery) (LocationPlayerRankingPager, error) {
+	return i.SeasonPlayerRankingsContext(context.Background(), id, query)
+}
+
+// Like SeasonPlayerRankings, but bound to ctx.
+func (i *LocationService) SeasonPlayerRankingsContext(ctx context.Context, id string, query *PagedQuery) (LocationPlayerRankingPager, error) {
+	req, err := i.c.newQueryRequest(ctx, fmt.Sprintf("/v1/locations/%s/seasons/%s/rankings/players", i.id, id), query)
+
+	var rankings LocationPlayerRankingPager
+
+	if err == nil {
+		_, err = i.c.Do(req, &rankings)
+	}
+
+	rankings.cursor = newCursor(query, func(ctx context.Context, page PagedQuery) (LocationPlayerRankingPager, error) {
+		return i.SeasonPlayerRankingsContext(ctx, id, &page)
+	})
+
+	return rankings, err
+}
+
+// Iterate over the top players of a league season, fetching pages as needed. query sets the page size
+// and starting cursor and may be nil; iteration stops after maxItems rankings unless it is zero.
+func (i *LocationService) SeasonPlayerRankingsIter(ctx context.Context, id string, query *PagedQuery, maxItems int) iter.Seq2[PlayerRanking, error] {
+	return paginate(ctx, query, maxItems, func(ctx context.Context, page *PagedQuery) ([]PlayerRanking, Paging, error) {
+		rankings, err := i.SeasonPlayerRankingsContext(ctx, id, page)
+		return rankings.Items, rankings.Paging, err
+	})
+}
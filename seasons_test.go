@@ -0,0 +1,116 @@
+package clash_test
+
+import (
+	"encoding/json"
+	"github.com/fiskie/go-clash"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// serves the season endpoints, recording each request's path and query.
+func seasonServer(requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.Path+"?"+r.URL.RawQuery)
+
+		switch r.URL.Path {
+		case "/v1/locations/global/seasons":
+			w.Write([]byte(`{"items":[{"id":"2023-05"},{"id":"2023-06"}]}`))
+		case "/v1/locations/global/seasonsV2":
+			w.Write([]byte(`{"items":[{"code":"2023-06","uniqueId":95,"endTime":"20230703T090000.000Z"}]}`))
+		case "/v1/locations/global/seasons/2023-06":
+			w.Write([]byte(`{"id":"2023-06"}`))
+		default:
+			if r.URL.Query().Get("after") != "" {
+				w.Write([]byte(`{"items":[{"tag":"#3","eloRating":2900,"rank":3}],"paging":{"cursors":{"before":"b"}}}`))
+				return
+			}
+
+			w.Write([]byte(`{"items":[{"tag":"#1","eloRating":3100,"rank":1},{"tag":"#2","eloRating":3000,"rank":2}],"paging":{"cursors":{"after":"a"}}}`))
+		}
+	}))
+}
+
+func TestLocationService_PathOfLegendRankings(t *testing.T) {
+	var requests []string
+	srv := seasonServer(&requests)
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	first, err := client.Location("57000000").PathOfLegendRankings(&clash.PagedQuery{Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 3100, first.Items[0].EloRating)
+
+	second, err := first.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "#3", second.Items[0].Tag)
+
+	_, err = second.Next()
+	assert.Equal(t, clash.ErrNoMorePages, err)
+
+	assert.Equal(t, []string{
+		"/v1/locations/57000000/pathoflegend/players?limit=2",
+		"/v1/locations/57000000/pathoflegend/players?after=a&limit=2",
+	}, requests)
+}
+
+func TestLocationService_SeasonPlayerRankings(t *testing.T) {
+	var requests []string
+	srv := seasonServer(&requests)
+	defer srv.Close()
+
+	client := clash.NewClient("token", clash.WithBaseURL(srv.URL))
+
+	first, err := client.Location("global").SeasonPlayerRankings("2023-06", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(first.Items))
+
+	second, err := first.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, second.Items[0].Rank)
+
+	assert.Equal(t, []string{
+		"/v1/locations/global/seasons/2023-06/rankings/players?",
+		"/v1/locations/global/seasons/2023-06/rankings/players?after=a",
+	}, requests)
+}
+
+func TestLocationService_Seasons(t *testing.T) {
+	var requests []string
+	srv := seasonServer(&requests)
+	defer srv.Close()
+
+	location := clash.NewClient("token", clash.WithBaseURL(srv.URL)).Location("global")
+
+	seasons, err := location.Seasons()
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-06", seasons.Items[1].ID)
+
+	season, err := location.Season("2023-06")
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-06", season.ID)
+
+	seasonsV2, err := location.SeasonsV2()
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-06", seasonsV2.Items[0].Code)
+	assert.Equal(t, 95, seasonsV2.Items[0].UniqueID)
+	assert.Equal(t, int64(1688374800), seasonsV2.Items[0].EndTime.Unix())
+}
+
+func TestPlayer_PathOfLegendSeasonResults(t *testing.T) {
+	var player clash.Player
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"tag": "#111",
+		"currentPathOfLegendSeasonResult": {"leagueNumber": 4, "trophies": 0, "rank": null},
+		"lastPathOfLegendSeasonResult": {"leagueNumber": 10, "trophies": 2950, "rank": 1234},
+		"bestPathOfLegendSeasonResult": {"leagueNumber": 10, "trophies": 3120, "rank": 87}
+	}`), &player))
+
+	assert.Equal(t, 4, player.CurrentPathOfLegendSeasonResult.LeagueNumber)
+	assert.Equal(t, 0, player.CurrentPathOfLegendSeasonResult.Rank)
+	assert.Equal(t, 2950, player.LastPathOfLegendSeasonResult.Trophies)
+	assert.Equal(t, 1234, player.LastPathOfLegendSeasonResult.Rank)
+	assert.Equal(t, 87, player.BestPathOfLegendSeasonResult.Rank)
+}
@@ -0,0 +1,83 @@
+package clash
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A timestamp in the API's format (see TimeLayout). Absent, null and empty timestamps decode to the zero
+// Time, which can be checked with IsZero; malformed ones fail to decode.
+type Time struct {
+	time.Time
+	// The timestamp as sent by the API. Empty if it was absent. Encoding reuses it only while it matches Time.
+	Raw string
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = Time{}
+		return nil
+	}
+
+	var raw string
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("clash: timestamp must be a string: %w", err)
+	}
+
+	return t.UnmarshalText([]byte(raw))
+}
+
+// Parse a timestamp in the API's format. The text methods override time.Time's RFC 3339 ones, so map keys
+// and other text encodings use the same layout as JSON.
+func (t *Time) UnmarshalText(data []byte) error {
+	raw := string(data)
+
+	if raw == "" {
+		*t = Time{}
+		return nil
+	}
+
+	parsed, err := time.Parse(TimeLayout, raw)
+
+	if err != nil {
+		return fmt.Errorf("clash: invalid timestamp %q: %w", raw, err)
+	}
+
+	*t = Time{parsed, raw}
+	return nil
+}
+
+// Encode the timestamp in the API's format, or null if it is zero.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.Time.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.format())
+}
+
+// Encode the timestamp in the API's format, or as empty text if it is zero.
+func (t Time) MarshalText() ([]byte, error) {
+	return t.AppendText(nil)
+}
+
+// Like MarshalText, but appends to b. This also overrides the promoted time.Time method, which encoders
+// may prefer to MarshalText.
+func (t Time) AppendText(b []byte) ([]byte, error) {
+	if t.Time.IsZero() {
+		return b, nil
+	}
+
+	return append(b, t.format()...), nil
+}
+
+// the timestamp as sent by the API if Time hasn't been changed since, otherwise Time in the API's format.
+func (t Time) format() string {
+	if parsed, err := time.Parse(TimeLayout, t.Raw); err == nil && parsed.Equal(t.Time) {
+		return t.Raw
+	}
+
+	return t.Time.UTC().Format(TimeLayout)
+}
@@ -1,13 +1,15 @@
 package clash
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"time"
 )
 
 type TournamentQuery struct {
 	PagedQuery
-	Name string
+	Name string `url:"name,omitempty"`
 }
 
 type TournamentMember struct {
@@ -20,8 +22,8 @@ type TournamentMember struct {
 
 type Tournament struct {
 	Tag                 string             `json:"tag"`
-	Type                string             `json:"type"`
-	Status              string             `json:"status"`
+	Type                TournamentType     `json:"type"`
+	Status              TournamentStatus   `json:"status"`
 	CreatorTag          string             `json:"creatorTag"`
 	Name                string             `json:"name"`
 	Description         string             `json:"description"`
@@ -29,27 +31,55 @@ type Tournament struct {
 	MaxCapacity         int                `json:"maxCapacity"`
 	PreparationDuration int                `json:"preparationDuration"`
 	Duration            int                `json:"duration"`
-	RawCreatedTime      string             `json:"createdTime"`
-	RawStartedTime      string             `json:"startedTime"`
+	CreatedTime         Time               `json:"createdTime"`
+	StartedTime         Time               `json:"startedTime"`
 	MembersList         []TournamentMember `json:"membersList"`
 	FirstPlaceCardPrize int                `json:"firstPlaceCardPrize"`
 	GameMode            GameMode           `json:"gameMode"`
 	LevelCap            int                `json:"levelCap"`
 }
 
-func (t *Tournament) CreatedTime() time.Time {
-	parsed, _ := time.Parse(TimeLayout, t.RawCreatedTime)
-	return parsed
+type TournamentPager = Page[Tournament]
+
+type SurvivalMilestoneReward struct {
+	Chest    string `json:"chest,omitempty"`
+	Rarity   string `json:"rarity,omitempty"`
+	Resource string `json:"resource,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Amount   int    `json:"amount"`
+	Wins     int    `json:"wins"`
+}
+
+type GlobalTournament struct {
+	Tag              string                    `json:"tag"`
+	Title            string                    `json:"title"`
+	StartTime        Time                      `json:"startTime"`
+	EndTime          Time                      `json:"endTime"`
+	MaxLosses        int                       `json:"maxLosses"`
+	MinExpLevel      int                       `json:"minExpLevel"`
+	TournamentLevel  int                       `json:"tournamentLevel"`
+	GameMode         GameMode                  `json:"gameMode"`
+	MilestoneRewards []SurvivalMilestoneReward `json:"milestoneRewards"`
+	FreeTierRewards  []SurvivalMilestoneReward `json:"freeTierRewards"`
+	TopRankReward    []SurvivalMilestoneReward `json:"topRankReward"`
+	MaxTopRewardRank int                       `json:"maxTopRewardRank"`
+}
+
+// Whether the tournament is running at the given time.
+func (t *GlobalTournament) ActiveAt(at time.Time) bool {
+	return !at.Before(t.StartTime.Time) && at.Before(t.EndTime.Time)
+}
+
+type GlobalTournamentList struct {
+	Items []GlobalTournament `json:"items"`
 }
 
-func (t *Tournament) StartedTime() time.Time {
-	parsed, _ := time.Parse(TimeLayout, t.RawStartedTime)
-	return parsed
+type GlobalTournamentsService struct {
+	c *Client
 }
 
-type TournamentPager struct {
-	Items  []Tournament `json:"items"`
-	Paging Paging       `json:"paging"`
+func (c *Client) GlobalTournaments() *GlobalTournamentsService {
+	return &GlobalTournamentsService{c}
 }
 
 type TournamentService struct {
@@ -71,8 +101,13 @@ func (c *Client) Tournament(tag string) *TournamentService {
 
 // Get information about a single tournament by a tournament tag.
 func (i *TournamentService) Get() (Tournament, error) {
+	return i.GetContext(context.Background())
+}
+
+// Like Get, but bound to ctx.
+func (i *TournamentService) GetContext(ctx context.Context) (Tournament, error) {
 	url := fmt.Sprintf("/v1/tournaments/%s", NormaliseTag(i.tag))
-	req, err := i.c.NewRequest("GET", url, nil)
+	req, err := i.c.NewRequestWithContext(ctx, "GET", url, nil)
 	var tournament Tournament
 
 	if err == nil {
@@ -87,26 +122,55 @@ func (i *TournamentService) Get() (Tournament, error) {
 // It is not possible to specify ordering for results so clients should not
 // rely on any specific ordering as that may change in the future releases of the API.
 func (i *TournamentsService) Search(query *TournamentQuery) (TournamentPager, error) {
-	req, err := i.c.NewRequest("GET", "/v1/tournaments", nil)
-	q := req.URL.Query()
-
-	q.Add("name", query.Name)
+	return i.SearchContext(context.Background(), query)
+}
 
-	if query.Limit > 0 {
-		q.Add("limit", fmt.Sprintf("%d", query.Limit))
+// Like Search, but bound to ctx.
+func (i *TournamentsService) SearchContext(ctx context.Context, query *TournamentQuery) (TournamentPager, error) {
+	if query == nil {
+		query = &TournamentQuery{}
 	}
 
-	if query.After > 0 {
-		q.Add("after", fmt.Sprintf("%d", query.After))
+	req, err := i.c.newQueryRequest(ctx, "/v1/tournaments", query)
+	var tournaments TournamentPager
+
+	if err == nil {
+		_, err = i.c.Do(req, &tournaments)
 	}
 
-	if query.Before > 0 {
-		q.Add("before", fmt.Sprintf("%d", query.Before))
+	tournaments.cursor = newCursor(&query.PagedQuery, func(ctx context.Context, page PagedQuery) (TournamentPager, error) {
+		next := *query
+		next.PagedQuery = page
+		return i.SearchContext(ctx, &next)
+	})
+
+	return tournaments, err
+}
+
+// Iterate over every tournament matching the query, fetching pages as needed. The query's Limit sets the page size;
+// iteration stops after maxItems tournaments unless it is zero.
+func (i *TournamentsService) SearchIter(ctx context.Context, query *TournamentQuery, maxItems int) iter.Seq2[Tournament, error] {
+	if query == nil {
+		query = &TournamentQuery{}
 	}
 
-	req.URL.RawQuery = q.Encode()
+	return paginate(ctx, &query.PagedQuery, maxItems, func(ctx context.Context, page *PagedQuery) ([]Tournament, Paging, error) {
+		next := *query
+		next.PagedQuery = *page
+		tournaments, err := i.SearchContext(ctx, &next)
+		return tournaments.Items, tournaments.Paging, err
+	})
+}
+
+// Get list of global tournaments.
+func (i *GlobalTournamentsService) All() (GlobalTournamentList, error) {
+	return i.AllContext(context.Background())
+}
 
-	var tournaments TournamentPager
+// Like All, but bound to ctx.
+func (i *GlobalTournamentsService) AllContext(ctx context.Context) (GlobalTournamentList, error) {
+	req, err := i.c.NewRequestWithContext(ctx, "GET", "/v1/globaltournaments", nil)
+	var tournaments GlobalTournamentList
 
 	if err == nil {
 		_, err = i.c.Do(req, &tournaments)